@@ -0,0 +1,25 @@
+//go:build linux
+
+package whTimer
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// setAffinity 将当前线程绑定到指定 CPU
+func setAffinity(cpus []int) error {
+	var mask [16]uint64 // 支持 1024 个 CPU
+	for _, cpu := range cpus {
+		if cpu < 0 || cpu >= len(mask)*64 {
+			continue
+		}
+		mask[cpu/64] |= 1 << (cpu % 64)
+	}
+	_, _, errno := syscall.RawSyscall(syscall.SYS_SCHED_SETAFFINITY, 0,
+		uintptr(len(mask)*8), uintptr(unsafe.Pointer(&mask)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
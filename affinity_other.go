@@ -0,0 +1,12 @@
+//go:build !linux
+
+package whTimer
+
+import (
+	"errors"
+)
+
+// setAffinity 非 Linux 平台不支持 CPU 绑定
+func setAffinity(cpus []int) error {
+	return errors.New("whTimer: cpu affinity is only supported on linux")
+}
@@ -0,0 +1,83 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// autoTuneMinSamples 一个调优周期内少于该数量的任务时保持上次的选择
+const autoTuneMinSamples = 64
+
+// Tuning 自动调优根据观察到的延迟分布选定的参数
+type Tuning struct {
+	HeapLimit int    // 任务数少于该值时使用 4 叉堆，同 WithSmallHeap
+	MinLevel  int    // 时间轮创建与降级时保持的最低层级
+	Samples   uint64 // 本次选择依据的任务数，0 表示尚未调优
+}
+
+// autoTuner 按周期统计延迟分布并据此调整小堆阈值与时间轮层级
+type autoTuner struct {
+	every  time.Duration
+	next   time.Time
+	window DelayStats // 本周期内新添加任务的延迟分布
+	last   Tuning
+}
+
+// WithAutoTune 每隔 every 根据该周期内新添加任务的延迟分布调整时间轮参数，覆盖 WithSmallHeap 的设置
+// 只调整以下两项；每层槽数（64，对应 uint64 位图）与 1ms 基准刻度是编译期常量，不随负载调整：
+//   - 最低层级：取 99 分位延迟所在层级，时间轮重新创建时直接建到该层，空闲时也不降到该层以下，
+//     避免延迟跨层的工作负载反复升降级
+//   - 小堆阈值：取 4^(中位延迟层级+1)，即堆操作的比较层数 log4(n) 不超过任务在时间轮中逐层下放的次数
+//
+// 周期内任务少于 64 个时保持上次的选择
+func WithAutoTune(every time.Duration) Option {
+	return func(t *Timer) {
+		if every <= 0 {
+			t.tuner = nil
+			return
+		}
+		t.tuner = &autoTuner{every: every}
+	}
+}
+
+// Tuning 返回自动调优最近一次选定的参数，未开启 WithAutoTune 时返回零值
+func (t *Timer) Tuning() Tuning {
+	var tn Tuning
+	t.inspect(func() {
+		if t.tuner != nil {
+			tn = t.tuner.last
+		}
+	})
+	return tn
+}
+
+// maybeTune 到达调优周期时根据延迟分布选择参数，仅在 run loop 中调用
+func (t *Timer) maybeTune() {
+	a := t.tuner
+	if a == nil {
+		return
+	}
+	now := t.now()
+	if a.next.IsZero() {
+		a.next = now.Add(a.every)
+		return
+	}
+	if now.Before(a.next) {
+		return
+	}
+	a.next = now.Add(a.every)
+	if a.window.Total() < autoTuneMinSamples {
+		return
+	}
+	a.last = Tuning{
+		HeapLimit: 1 << (2 * (max(a.window.Quantile(0.5), 0) + 1)),
+		MinLevel:  max(a.window.Quantile(0.99), 0),
+		Samples:   a.window.Total(),
+	}
+	a.window = DelayStats{}
+
+	t.heapLimit = a.last.HeapLimit
+	t.minLevel = a.last.MinLevel
+	for t.wheel != nil && t.wheel.Level() < t.minLevel {
+		t.wheel = t.wheel.LevelUp()
+	}
+}
@@ -0,0 +1,43 @@
+package whTimer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimerAutoTune(t *testing.T) {
+	var fired atomic.Int64
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithAutoTune(time.Second))
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+	for i := 0; i < 100; i++ {
+		delay := time.Second
+		if i%10 == 0 {
+			delay = time.Minute
+		}
+		timer.AddEntry(delay, func() { fired.Add(1) })
+	}
+	timer.Advance(base)
+	timer.Advance(base.Add(2 * time.Minute))
+
+	// 中位延迟在第 1 层、99 分位在第 2 层
+	want := Tuning{HeapLimit: 16, MinLevel: 2, Samples: 100}
+	if got := timer.Tuning(); got != want || fired.Load() != 100 {
+		t.Fatalf("tuning = %+v, fired %d", got, fired.Load())
+	}
+
+	// 超出堆阈值后迁入的时间轮直接建到选定层级，短延迟任务照常触发
+	now := base.Add(2 * time.Minute)
+	for i := 0; i < 20; i++ {
+		timer.AddEntry(5*time.Millisecond, func() { fired.Add(1) })
+	}
+	timer.Advance(now)
+	if timer.wheel == nil || timer.wheel.Level() != 2 {
+		t.Fatalf("wheel not built at the tuned level")
+	}
+	timer.Advance(now.Add(10 * time.Millisecond))
+	if fired.Load() != 120 {
+		t.Fatalf("fired %d, want 120", fired.Load())
+	}
+}
@@ -0,0 +1,97 @@
+package whTimer
+
+import (
+	"fmt"
+	"time"
+)
+
+// BackoffPolicy 周期任务连续失败后的退避策略
+type BackoffPolicy struct {
+	Threshold int           // 连续失败达到该次数后开始退避，默认 3
+	Base      time.Duration // 首次退避时长，之后每次失败翻倍，默认 1s
+	Max       time.Duration // 退避上限，默认 1h
+	OnError   func(error)   // 每次失败时调用（可为 nil），panic 被转换为 error
+}
+
+func (p BackoffPolicy) penalty(failures int) time.Duration {
+	threshold := p.Threshold
+	if threshold <= 0 {
+		threshold = 3
+	}
+	if failures < threshold {
+		return 0
+	}
+	base, limit := p.Base, p.Max
+	if base <= 0 {
+		base = time.Second
+	}
+	if limit <= 0 {
+		limit = time.Hour
+	}
+	d := base
+	for i := threshold; i < failures && d < limit; i++ {
+		d *= 2
+	}
+	return min(d, limit)
+}
+
+// AddScheduleWithBackoff 按自定义调度规则创建可失败的周期任务，退避规则同 CronWithBackoff
+func (t *Timer) AddScheduleWithBackoff(schedule Schedule, policy BackoffPolicy, fn func() error) *CronEntry {
+	return t.addScheduleWithBackoff("", schedule, policy, fn)
+}
+
+func (t *Timer) addScheduleWithBackoff(spec string, schedule Schedule, policy BackoffPolicy, fn func() error) *CronEntry {
+	c := t.newCron(spec, nil)
+	c.schedule = schedule
+
+	var fire func()
+	scheduleNext := func() {
+		if c.stopped.Load() {
+			return
+		}
+		next := schedule.Next(t.now().Add(c.Penalty()))
+		if next.IsZero() {
+			c.finish()
+			return
+		}
+		c.arm(next, fire)
+	}
+	fire = func() {
+		if c.stopped.Load() {
+			return
+		}
+		if err := callSafely(fn); err != nil {
+			failures := c.failures.Add(1)
+			c.penalty.Store(int64(policy.penalty(int(failures))))
+			if policy.OnError != nil {
+				policy.OnError(err)
+			}
+		} else {
+			c.failures.Store(0)
+			c.penalty.Store(0)
+		}
+		scheduleNext()
+	}
+	scheduleNext()
+	return c
+}
+
+// callSafely 调用 fn 并将 panic 转换为 error
+func callSafely(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("whTimer: panic in job: %v", r)
+		}
+	}()
+	return fn()
+}
+
+// Failures 返回连续失败次数
+func (c *CronEntry) Failures() int {
+	return int(c.failures.Load())
+}
+
+// Penalty 返回当前退避时长，未退避时为 0
+func (c *CronEntry) Penalty() time.Duration {
+	return time.Duration(c.penalty.Load())
+}
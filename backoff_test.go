@@ -0,0 +1,77 @@
+package whTimer
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCronBackoff(t *testing.T) {
+	policy := BackoffPolicy{Threshold: 2, Base: 10 * time.Millisecond, Max: 30 * time.Millisecond}
+	for failures, want := range []time.Duration{0, 0, 10, 20, 30, 30} {
+		if got := policy.penalty(failures); got != want*time.Millisecond {
+			t.Errorf("penalty(%d)=%v, want %vms", failures, got, want)
+		}
+	}
+
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	var runs atomic.Int32
+	var errs atomic.Int32
+	policy.OnError = func(error) { errs.Add(1) }
+	recovered := make(chan struct{})
+	every := ScheduleFunc(func(now time.Time) time.Time { return now.Add(time.Millisecond) })
+	c := timer.AddScheduleWithBackoff(every, policy, func() error {
+		switch n := runs.Add(1); {
+		case n == 1:
+			panic("boom")
+		case n <= 3:
+			return errors.New("fail")
+		case n == 4:
+			close(recovered)
+		}
+		return nil
+	})
+	defer c.Stop()
+
+	select {
+	case <-recovered:
+	case <-time.After(time.Second):
+		t.Fatal("job did not recover")
+	}
+	deadline := time.Now().Add(time.Second)
+	for c.Failures() != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if errs.Load() != 3 || c.Failures() != 0 || c.Penalty() != 0 {
+		t.Fatalf("errs=%d failures=%d penalty=%v", errs.Load(), c.Failures(), c.Penalty())
+	}
+}
+
+func TestBackoffManualClock(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	// 退避以定时器时间为准，不受墙钟影响
+	every := ScheduleFunc(func(now time.Time) time.Time { return now.Add(10 * time.Millisecond) })
+	var failing int
+	policy := BackoffPolicy{Threshold: 1, Base: 20 * time.Millisecond, Max: 20 * time.Millisecond}
+	backoff := timer.AddScheduleWithBackoff(every, policy, func() error {
+		failing++
+		return errors.New("fail")
+	})
+	if !backoff.Next().Equal(base.Add(10 * time.Millisecond)) {
+		t.Fatalf("backoff next = %v", backoff.Next())
+	}
+	for ms := 1; ms <= 30; ms++ {
+		timer.Advance(base.Add(time.Duration(ms) * time.Millisecond))
+	}
+	// 10ms 失败后推迟 20ms：下一次在 40ms
+	if failing != 1 || !backoff.Next().Equal(base.Add(40*time.Millisecond)) {
+		t.Fatalf("backoff: failing=%d next=%v", failing, backoff.Next())
+	}
+}
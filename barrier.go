@@ -0,0 +1,70 @@
+package whTimer
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Barrier 一组条目全部结束（执行完成、取消或丢弃）后执行收尾回调
+type Barrier struct {
+	remaining atomic.Int64
+
+	mu   sync.Mutex
+	done bool
+	fns  []func()
+}
+
+// NewBarrier 创建等待 entries 全部结束的屏障，应在条目触发前调用
+// 条目由 handler 调用 Execute 且回调返回后才算执行完成，重复执行的任务在最后一次执行后结束；
+// 调用时已结束的条目直接计为完成，entries 为空时屏障立即完成
+func NewBarrier(entries ...*Entry) *Barrier {
+	b := &Barrier{}
+	b.remaining.Store(int64(len(entries)) + 1)
+	for _, e := range entries {
+		if !e.watch(e.state.Load()>>genShift, b.arrive) {
+			b.arrive()
+		}
+	}
+	// 注册期间先结束的条目不会提前完成屏障
+	b.arrive()
+	return b
+}
+
+// arrive 一个条目结束
+func (b *Barrier) arrive() {
+	if b.remaining.Add(-1) != 0 {
+		return
+	}
+	b.mu.Lock()
+	b.done = true
+	fns := b.fns
+	b.fns = nil
+	b.mu.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// Then 注册全部结束后执行的回调，可多次调用
+// 回调在最后一个结束的条目所在的 goroutine 中执行，屏障已完成时立即在调用方执行
+func (b *Barrier) Then(fn func()) *Barrier {
+	b.mu.Lock()
+	if !b.done {
+		b.fns = append(b.fns, fn)
+		b.mu.Unlock()
+		return b
+	}
+	b.mu.Unlock()
+	fn()
+	return b
+}
+
+// Remaining 返回尚未结束的条目数
+func (b *Barrier) Remaining() int {
+	return int(b.remaining.Load())
+}
+
+// Done 检查是否所有条目均已结束
+func (b *Barrier) Done() bool {
+	return b.remaining.Load() == 0
+}
@@ -0,0 +1,47 @@
+package whTimer
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBarrier(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	var order []string
+	a := timer.AddEntry(10*time.Millisecond, func() { order = append(order, "a") })
+	b := timer.AddEntry(20*time.Millisecond, func() { order = append(order, "b") })
+	c := timer.AddEntry(30*time.Millisecond, func() { order = append(order, "c") })
+	r := timer.AddRepeating(5*time.Millisecond, 10*time.Millisecond, 3, func() { order = append(order, "r") })
+	barrier := NewBarrier(a, b, c, r).Then(func() { order = append(order, "then") })
+	if barrier.Remaining() != 4 {
+		t.Fatalf("Remaining=%d", barrier.Remaining())
+	}
+
+	c.Cancel()
+	timer.Advance(base.Add(20 * time.Millisecond))
+	if barrier.Done() || barrier.Remaining() != 1 {
+		t.Fatalf("Done=%v Remaining=%d before repeating entry finished", barrier.Done(), barrier.Remaining())
+	}
+	timer.Advance(base.Add(25 * time.Millisecond))
+	if !barrier.Done() {
+		t.Fatal("barrier not done")
+	}
+	want := []string{"a", "b", "r", "r", "r", "then"}
+	if !slices.Equal(order, want) {
+		t.Fatalf("order=%q, want %q", order, want)
+	}
+
+	var late bool
+	barrier.Then(func() { late = true })
+	if !late {
+		t.Fatal("Then on a completed barrier did not run immediately")
+	}
+	NewBarrier().Then(func() { late = false })
+	if late {
+		t.Fatal("empty barrier did not complete")
+	}
+}
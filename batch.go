@@ -0,0 +1,43 @@
+package whTimer
+
+// batcher WithBatchHandler 的批量 handler 与本轮收集、等待交给它的条目
+type batcher struct {
+	handler func(entries []*Entry)
+	entries []*Entry
+}
+
+// WithBatchHandler 以批量方式交付到期任务：一轮 run loop 内本应交给默认 handler 的条目收集为切片，
+// 在该轮结束时调用一次 fn，便于合并下游工作（例如一次写库代替上万次单条写入）
+// fn 在 run loop 中调用，负责对每个条目调用 Execute 或 Release；切片在 fn 返回后被复用，不应保留
+// 设置后 NewTimer 的 handler 与 SetHandler 不再生效（可传 nil），Handle 路由与 SubTimer 的条目仍逐个交付
+func WithBatchHandler(fn func(entries []*Entry)) Option {
+	return func(t *Timer) {
+		t.batch.handler = fn
+	}
+}
+
+// collect 将交给默认 handler 的条目加入本轮批次，返回是否已加入
+func (t *Timer) collect(entry *Entry) bool {
+	if entry.handler != nil {
+		return false
+	}
+	if routes := t.routes.Load(); routes != nil {
+		if _, ok := (*routes)[entry.tag]; ok {
+			return false
+		}
+	}
+	t.batch.entries = append(t.batch.entries, entry)
+	return true
+}
+
+// flushBatch 将本轮收集的条目一次交给批量 handler
+func (t *Timer) flushBatch() {
+	if len(t.batch.entries) == 0 {
+		return
+	}
+	t.enterCallback()
+	t.batch.handler(t.batch.entries)
+	t.exitCallback()
+	clear(t.batch.entries)
+	t.batch.entries = t.batch.entries[:0]
+}
@@ -0,0 +1,38 @@
+package whTimer
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimerBatchHandler(t *testing.T) {
+	var batches [][]string
+	timer := NewTimer(nil, WithBatchHandler(func(entries []*Entry) {
+		var tags []string
+		for _, e := range entries {
+			tags = append(tags, e.Tag())
+			e.Execute()
+		}
+		batches = append(batches, tags)
+	}))
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	var routed, ran int
+	timer.Handle("audit", func(e *Entry) {
+		routed++
+		e.Execute()
+	})
+	for _, tag := range []string{"a", "b", "audit", "c"} {
+		timer.AddTaggedEntry(tag, 10*time.Millisecond, func() { ran++ })
+	}
+	timer.AddTaggedEntry("d", 20*time.Millisecond, func() { ran++ })
+
+	timer.Advance(base.Add(10 * time.Millisecond))
+	timer.Advance(base.Add(20 * time.Millisecond))
+	want := [][]string{{"a", "b", "c"}, {"d"}}
+	if !slices.EqualFunc(batches, want, slices.Equal) || routed != 1 || ran != 5 {
+		t.Fatalf("batches=%v routed=%d ran=%d, want %v 1 5", batches, routed, ran, want)
+	}
+}
@@ -0,0 +1,227 @@
+// Package benchharness 可复用的定时器基准测试工具
+// 以编程方式运行与仓库基准测试相同的负载矩阵（任务数 × 延迟），
+// 可在调用方自己的环境中对比 whTimer、标准库以及实现了 Scheduler 接口的其他时间轮
+package benchharness
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"whTimer"
+)
+
+// Scheduler 被测的一次性定时器实现
+type Scheduler interface {
+	AfterFunc(d time.Duration, fn func())
+	Stop()
+}
+
+// Impl 具名的定时器实现，每轮测试通过 New 创建新实例
+type Impl struct {
+	Name string
+	New  func() Scheduler
+}
+
+// CronScheduler 被测的周期任务实现
+type CronScheduler interface {
+	Every(d time.Duration, fn func()) (stop func())
+	Stop()
+}
+
+// CronImpl 具名的周期任务实现
+type CronImpl struct {
+	Name string
+	New  func() CronScheduler
+}
+
+// Case 全流程测试用例：添加 Count 条延迟 Delay 的任务并等待全部执行
+type Case struct {
+	Count int
+	Delay time.Duration
+}
+
+// Name 返回用例名称，如 "10K_delay-5s"
+func (c Case) Name() string {
+	return fmt.Sprintf("%s_delay-%s", FormatCount(c.Count), formatDuration(c.Delay))
+}
+
+// DefaultCases 仓库基准测试使用的负载矩阵
+var DefaultCases = []Case{
+	{100, 1 * time.Second},
+	{10000, 5 * time.Second},
+	{1000000, 30 * time.Second},
+	{100000000, 1 * time.Minute},
+}
+
+// Result 一轮测试的结果
+type Result struct {
+	Impl      string
+	Case      Case
+	Elapsed   time.Duration // 从开始添加到全部执行完成的总耗时
+	NsPerTask float64       // (总耗时 - 延迟) / 任务数
+}
+
+// WhTimer 返回 whTimer 实现，每个实例为一个新启动的 Timer
+func WhTimer(opts ...whTimer.Option) Impl {
+	return Impl{Name: "whTimer", New: func() Scheduler {
+		t := whTimer.NewTimer(func(e *whTimer.Entry) { e.Execute() }, opts...)
+		t.Start()
+		return whTimerScheduler{t}
+	}}
+}
+
+type whTimerScheduler struct{ t *whTimer.Timer }
+
+func (s whTimerScheduler) AfterFunc(d time.Duration, fn func()) { s.t.AddEntry(d, fn) }
+func (s whTimerScheduler) Stop()                                { s.t.Stop() }
+
+// Stdlib 返回标准库 time.AfterFunc 实现
+func Stdlib() Impl {
+	return Impl{Name: "Stdlib", New: func() Scheduler { return stdlibScheduler{} }}
+}
+
+type stdlibScheduler struct{}
+
+func (stdlibScheduler) AfterFunc(d time.Duration, fn func()) { time.AfterFunc(d, fn) }
+func (stdlibScheduler) Stop()                                {}
+
+// WhTimerCron 返回基于 CronInterval 的 whTimer 周期任务实现
+func WhTimerCron(opts ...whTimer.Option) CronImpl {
+	return CronImpl{Name: "whTimer", New: func() CronScheduler {
+		t := whTimer.NewTimer(func(e *whTimer.Entry) { e.Execute() }, opts...)
+		t.Start()
+		return whTimerCron{t}
+	}}
+}
+
+type whTimerCron struct{ t *whTimer.Timer }
+
+func (s whTimerCron) Every(d time.Duration, fn func()) func() { return s.t.CronInterval(d, fn).Stop }
+func (s whTimerCron) Stop()                                   { s.t.Stop() }
+
+// RobfigCron 返回 robfig/cron 实现，间隔不足 1 秒时按 1 秒执行
+func RobfigCron() CronImpl {
+	return CronImpl{Name: "robfig", New: func() CronScheduler {
+		c := cron.New(cron.WithSeconds())
+		c.Start()
+		return robfigCron{c}
+	}}
+}
+
+type robfigCron struct{ c *cron.Cron }
+
+func (s robfigCron) Every(d time.Duration, fn func()) func() {
+	id := s.c.Schedule(cron.Every(d), cron.FuncJob(fn))
+	return func() { s.c.Remove(id) }
+}
+
+func (s robfigCron) Stop() { s.c.Stop() }
+
+// FullCycle 添加 c.Count 条延迟 c.Delay 的任务并等待全部执行完成
+// 超过 c.Delay+10s 仍未完成时返回错误
+func FullCycle(impl Impl, c Case) (Result, error) {
+	s := impl.New()
+	defer s.Stop()
+
+	var executed atomic.Int64
+	done := make(chan struct{})
+	target := int64(c.Count)
+	fn := func() {
+		if executed.Add(1) == target {
+			close(done)
+		}
+	}
+
+	start := time.Now()
+	for range c.Count {
+		s.AfterFunc(c.Delay, fn)
+	}
+	select {
+	case <-done:
+	case <-time.After(c.Delay + 10*time.Second):
+		return Result{}, fmt.Errorf("benchharness: %s %s: timeout, executed %d of %d", impl.Name, c.Name(), executed.Load(), target)
+	}
+
+	elapsed := time.Since(start)
+	return Result{
+		Impl:      impl.Name,
+		Case:      c,
+		Elapsed:   elapsed,
+		NsPerTask: float64((elapsed - c.Delay).Nanoseconds()) / float64(c.Count),
+	}, nil
+}
+
+// RunMatrix 对每个实现依次运行全部用例，遇到错误时停止
+func RunMatrix(impls []Impl, cases []Case) ([]Result, error) {
+	var results []Result
+	for _, impl := range impls {
+		for _, c := range cases {
+			r, err := FullCycle(impl, c)
+			if err != nil {
+				return results, err
+			}
+			results = append(results, r)
+		}
+	}
+	return results, nil
+}
+
+// CronCycle 创建 n 个间隔为 interval 的周期任务，等待每个任务至少执行一次后全部停止
+// 返回总耗时，超过 interval+10s 仍未完成时返回错误
+func CronCycle(impl CronImpl, n int, interval time.Duration) (time.Duration, error) {
+	s := impl.New()
+	defer s.Stop()
+
+	var pending atomic.Int64
+	pending.Store(int64(n))
+	done := make(chan struct{})
+
+	start := time.Now()
+	stops := make([]func(), n)
+	for i := range n {
+		var fired atomic.Bool
+		stops[i] = s.Every(interval, func() {
+			if !fired.Swap(true) && pending.Add(-1) == 0 {
+				close(done)
+			}
+		})
+	}
+
+	var err error
+	select {
+	case <-done:
+	case <-time.After(interval + 10*time.Second):
+		err = fmt.Errorf("benchharness: %s cron x%d: timeout, %d never fired", impl.Name, n, pending.Load())
+	}
+	elapsed := time.Since(start)
+	for _, stop := range stops {
+		stop()
+	}
+	return elapsed, err
+}
+
+// FormatCount 将任务数格式化为 100、10K、1M、100M 等形式
+func FormatCount(n int) string {
+	switch {
+	case n >= 1000000 && n%1000000 == 0:
+		return fmt.Sprintf("%dM", n/1000000)
+	case n >= 1000 && n%1000 == 0:
+		return fmt.Sprintf("%dK", n/1000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d == time.Minute:
+		return "1min"
+	case d%time.Second == 0 && d < time.Minute:
+		return fmt.Sprintf("%ds", d/time.Second)
+	default:
+		return d.String()
+	}
+}
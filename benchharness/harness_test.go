@@ -0,0 +1,119 @@
+package benchharness
+
+import (
+	"testing"
+	"time"
+)
+
+// 全流程测试：添加N条延迟D的任务，等待全部执行完成，计算平均耗时
+func BenchmarkFullCycle(b *testing.B) {
+	for _, impl := range []Impl{WhTimer(), Stdlib()} {
+		for _, c := range DefaultCases {
+			b.Run(impl.Name+"/"+c.Name(), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					r, err := FullCycle(impl, c)
+					if err != nil {
+						b.Fatal(err)
+					}
+					b.ReportMetric(r.NsPerTask, "ns/task")
+					b.ReportMetric(float64(c.Count), "tasks")
+				}
+			})
+		}
+	}
+}
+
+// 周期任务执行一轮的耗时
+func BenchmarkCronCycle(b *testing.B) {
+	impls := []struct {
+		impl     CronImpl
+		interval time.Duration
+	}{
+		{WhTimerCron(), 50 * time.Millisecond},
+		{RobfigCron(), time.Second}, // robfig/cron 的最小间隔为 1 秒
+	}
+	for _, tc := range impls {
+		for _, n := range []int{100, 1000, 10000} {
+			b.Run(tc.impl.Name+"/"+FormatCount(n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := CronCycle(tc.impl, n, tc.interval); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(n), "crons")
+			})
+		}
+	}
+}
+
+// 单次添加/删除周期任务
+func BenchmarkCronAdd(b *testing.B) {
+	for _, impl := range []CronImpl{WhTimerCron(), RobfigCron()} {
+		b.Run(impl.Name, func(b *testing.B) {
+			s := impl.New()
+			defer s.Stop()
+
+			b.ResetTimer()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				s.Every(time.Hour, func() {})()
+			}
+		})
+	}
+}
+
+// 批量创建周期任务的内存占用
+func BenchmarkCronMemory(b *testing.B) {
+	counts := map[string][]int{
+		"whTimer": {1000, 10000, 100000},
+		"robfig":  {1000, 10000}, // robfig/cron 10万会很慢
+	}
+	for _, impl := range []CronImpl{WhTimerCron(), RobfigCron()} {
+		for _, n := range counts[impl.Name] {
+			b.Run(impl.Name+"/"+FormatCount(n), func(b *testing.B) {
+				s := impl.New()
+				defer s.Stop()
+
+				b.ResetTimer()
+				b.ReportAllocs()
+				stops := make([]func(), n)
+				for i := 0; i < b.N; i++ {
+					for j := range stops {
+						stops[j] = s.Every(time.Hour, func() {})
+					}
+					for _, stop := range stops {
+						stop()
+					}
+				}
+				b.ReportMetric(float64(n), "crons")
+			})
+		}
+	}
+}
+
+func TestFullCycle(t *testing.T) {
+	for _, impl := range []Impl{WhTimer(), Stdlib()} {
+		r, err := FullCycle(impl, Case{Count: 100, Delay: 10 * time.Millisecond})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Impl != impl.Name || r.Elapsed < 10*time.Millisecond {
+			t.Fatalf("result=%+v", r)
+		}
+	}
+}
+
+func TestCronCycle(t *testing.T) {
+	if _, err := CronCycle(WhTimerCron(), 100, 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCaseName(t *testing.T) {
+	want := []string{"100_delay-1s", "10K_delay-5s", "1M_delay-30s", "100M_delay-1min"}
+	for i, c := range DefaultCases {
+		if c.Name() != want[i] {
+			t.Errorf("name=%q, want %q", c.Name(), want[i])
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package whTimer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrStepBudgetExceeded 步骤用完分配的预算，为 Budget.Step 返回的 context 的 context.Cause
+var ErrStepBudgetExceeded = errors.New("whTimer: step budget exceeded")
+
+// Budget 总时间预算，按比例分配给顺序执行的各步骤，使多阶段处理整体满足 SLA
+type Budget struct {
+	timer    *Timer
+	parent   context.Context
+	total    time.Duration
+	deadline time.Time
+
+	mu     sync.Mutex
+	cancel context.CancelFunc // 当前步骤
+}
+
+// NewBudget 创建从现在起共 total 的预算
+func (t *Timer) NewBudget(parent context.Context, total time.Duration) *Budget {
+	return &Budget{
+		timer:    t,
+		parent:   parent,
+		total:    total,
+		deadline: t.now().Add(total),
+	}
+}
+
+// Step 结束上一步骤并开始新步骤，返回其 context
+// 步骤截止时间为现在起 total×fraction，且不晚于整体截止时间：前面的步骤提前完成时，
+// 节省的时间自然留给后续步骤；fraction >= 1 表示使用全部剩余预算
+// 截止时间由时间轮条目负责，到期后 Err 返回 context.DeadlineExceeded，
+// context.Cause 返回 ErrStepBudgetExceeded
+func (b *Budget) Step(fraction float64) context.Context {
+	at := b.deadline
+	if fraction < 1 {
+		at = b.timer.now().Add(time.Duration(float64(b.total) * max(fraction, 0)))
+		if at.After(b.deadline) {
+			at = b.deadline
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.cancel != nil {
+		b.cancel()
+	}
+	ctx, cancel, ref := b.timer.withWheelDeadline(b.parent, at, func() error {
+		return ErrStepBudgetExceeded
+	})
+	b.cancel = func() {
+		ref.Cancel()
+		cancel(context.Canceled)
+	}
+	return ctx
+}
+
+// Remaining 返回剩余的总预算
+func (b *Budget) Remaining() time.Duration {
+	return max(b.deadline.Sub(b.timer.now()), 0)
+}
+
+// Deadline 返回整体截止时间
+func (b *Budget) Deadline() time.Time {
+	return b.deadline
+}
+
+// Close 结束当前步骤并释放其时间轮条目
+func (b *Budget) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.cancel != nil {
+		b.cancel()
+		b.cancel = nil
+	}
+}
@@ -0,0 +1,48 @@
+package whTimer
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBudgetStep(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	type key struct{}
+	parent := context.WithValue(context.Background(), key{}, "req")
+	b := timer.NewBudget(parent, 100*time.Millisecond)
+	defer b.Close()
+
+	first := b.Step(0.3)
+	if d, _ := first.Deadline(); !d.Equal(base.Add(30*time.Millisecond)) || first.Value(key{}) != "req" {
+		t.Fatalf("first deadline=%v", d)
+	}
+	timer.Advance(base.Add(10 * time.Millisecond))
+	if first.Err() != nil {
+		t.Fatal("step expired early")
+	}
+
+	// 第一步提前完成，第二步从现在起计算且不超过整体截止时间
+	second := b.Step(0.95)
+	if first.Err() != context.Canceled {
+		t.Fatalf("previous step err=%v", first.Err())
+	}
+	if d, _ := second.Deadline(); !d.Equal(base.Add(100 * time.Millisecond)) {
+		t.Fatalf("second deadline=%v", d)
+	}
+	timer.Advance(base.Add(100 * time.Millisecond))
+	select {
+	case <-second.Done():
+	default:
+		t.Fatal("second step not done at deadline")
+	}
+	if second.Err() != context.DeadlineExceeded || context.Cause(second) != ErrStepBudgetExceeded {
+		t.Fatalf("err=%v cause=%v", second.Err(), context.Cause(second))
+	}
+	if b.Remaining() != 0 {
+		t.Fatalf("Remaining=%v", b.Remaining())
+	}
+}
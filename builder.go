@@ -0,0 +1,133 @@
+package whTimer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ScheduleBuilder 以链式调用组合周期规则，替代易错的 cron 表达式
+// 示例: timer.Every(5*time.Minute).Between(9, 17).OnWeekdays().Do(fn)
+type ScheduleBuilder struct {
+	timer    *Timer
+	every    time.Duration
+	startH   int
+	endH     int
+	weekdays uint8 // 按 time.Weekday 置位，0 表示不限
+	loc      *time.Location
+	err      error
+}
+
+// Every 创建按固定间隔触发的规则构建器
+// 间隔按每天时间窗口的起点对齐，默认窗口为全天
+func (t *Timer) Every(d time.Duration) *ScheduleBuilder {
+	b := &ScheduleBuilder{timer: t, every: d, endH: 24, loc: time.Local}
+	if d <= 0 {
+		b.err = fmt.Errorf("whTimer: invalid interval %v: must be positive", d)
+	}
+	return b
+}
+
+// Between 限制在每天 [startHour, endHour) 小时内触发
+func (b *ScheduleBuilder) Between(startHour, endHour int) *ScheduleBuilder {
+	if b.err == nil && (startHour < 0 || endHour > 24 || startHour >= endHour) {
+		b.err = fmt.Errorf("whTimer: invalid hour window %d-%d: want 0 <= start < end <= 24", startHour, endHour)
+	}
+	b.startH, b.endH = startHour, endHour
+	return b
+}
+
+// On 限制在指定的星期几触发，可多次调用累加
+func (b *ScheduleBuilder) On(days ...time.Weekday) *ScheduleBuilder {
+	for _, d := range days {
+		if d < time.Sunday || d > time.Saturday {
+			if b.err == nil {
+				b.err = fmt.Errorf("whTimer: invalid weekday %d", d)
+			}
+			continue
+		}
+		b.weekdays |= 1 << d
+	}
+	return b
+}
+
+// OnWeekdays 限制在周一到周五触发
+func (b *ScheduleBuilder) OnWeekdays() *ScheduleBuilder {
+	return b.On(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+}
+
+// OnWeekends 限制在周六、周日触发
+func (b *ScheduleBuilder) OnWeekends() *ScheduleBuilder {
+	return b.On(time.Saturday, time.Sunday)
+}
+
+// In 设置时间窗口与星期所在的时区，默认本地时区
+func (b *ScheduleBuilder) In(loc *time.Location) *ScheduleBuilder {
+	if loc == nil {
+		if b.err == nil {
+			b.err = errors.New("whTimer: nil location")
+		}
+		return b
+	}
+	b.loc = loc
+	return b
+}
+
+// Schedule 编译为 Schedule，可用于 AddSchedule
+func (b *ScheduleBuilder) Schedule() (Schedule, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	weekdays := b.weekdays
+	if weekdays == 0 {
+		weekdays = 1<<7 - 1
+	}
+	return &windowSchedule{
+		every:    b.every,
+		startH:   b.startH,
+		endH:     b.endH,
+		weekdays: weekdays,
+		loc:      b.loc,
+	}, nil
+}
+
+// Do 编译规则并创建周期任务
+func (b *ScheduleBuilder) Do(callback func()) (*CronEntry, error) {
+	s, err := b.Schedule()
+	if err != nil {
+		return nil, err
+	}
+	return b.timer.AddSchedule(s, callback), nil
+}
+
+// windowSchedule 每天时间窗口内按固定间隔触发
+type windowSchedule struct {
+	every    time.Duration
+	startH   int
+	endH     int
+	weekdays uint8
+	loc      *time.Location
+}
+
+// Next 返回 t 之后窗口内的下一个对齐时刻，至多向后查找一周
+func (s *windowSchedule) Next(t time.Time) time.Time {
+	year, month, day := t.In(s.loc).Date()
+	for i := 0; i <= 7; i++ {
+		start := time.Date(year, month, day+i, s.startH, 0, 0, 0, s.loc)
+		if s.weekdays&(1<<start.Weekday()) == 0 {
+			continue
+		}
+		end := time.Date(year, month, day+i, s.endH, 0, 0, 0, s.loc)
+		if !t.Before(end) {
+			continue
+		}
+		if t.Before(start) {
+			return start
+		}
+		next := start.Add((t.Sub(start)/s.every + 1) * s.every)
+		if next.Before(end) {
+			return next
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,26 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// AddEntryNoWake 添加定时任务但不检查睡眠截止时间、也不唤醒 run loop - Wait-Free
+// 用于批量预加载大量任务，省去每次添加的原子读取与通道发送；加载完成后调用一次 Kick，
+// 否则 run loop 要到下一次自行醒来才会处理这些任务
+func (t *Timer) AddEntryNoWake(delay time.Duration, callback func()) *Entry {
+	entry := t.newEntry(t.now().Add(delay), callback)
+	entry.delay = delay
+	t.queue.Push(entry)
+	return entry
+}
+
+// Kick 唤醒 run loop 处理已入队的任务，与 AddEntryNoWake 配合使用
+func (t *Timer) Kick() {
+	if t.onDemand {
+		t.ensureLoop()
+	}
+	select {
+	case t.wakeChan <- struct{}{}:
+	default:
+	}
+}
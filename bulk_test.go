@@ -0,0 +1,33 @@
+package whTimer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimerAddEntryNoWake(t *testing.T) {
+	var executed atomic.Int32
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	// run loop 空闲等待唤醒，未 Kick 前不会处理批量加载的任务
+	time.Sleep(5 * time.Millisecond)
+	for range 1000 {
+		timer.AddEntryNoWake(time.Millisecond, func() { executed.Add(1) })
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := executed.Load(); n != 0 {
+		t.Fatalf("%d entries fired before Kick", n)
+	}
+
+	timer.Kick()
+	deadline := time.Now().Add(time.Second)
+	for executed.Load() != 1000 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := executed.Load(); n != 1000 {
+		t.Fatalf("executed %d after Kick, want 1000", n)
+	}
+}
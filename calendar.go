@@ -0,0 +1,121 @@
+package whTimer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clockSchedule 每天（或每周指定一天）的固定时刻
+type clockSchedule struct {
+	hour, min, sec int
+	weekday        time.Weekday
+	weekly         bool
+	loc            *time.Location
+}
+
+// Next 返回 t 之后的下一个时刻，按日期逐日推进以正确处理夏令时切换
+func (s *clockSchedule) Next(t time.Time) time.Time {
+	local := t.In(s.loc)
+	year, month, day := local.Date()
+	for i := 0; i <= 7; i++ {
+		next := time.Date(year, month, day+i, s.hour, s.min, s.sec, 0, s.loc)
+		if !next.After(t) || (s.weekly && next.Weekday() != s.weekday) {
+			continue
+		}
+		return next
+	}
+	return time.Time{}
+}
+
+// parseClock 解析 "15:04" 或 "15:04:05" 格式的时刻
+func parseClock(clock string) (hour, min, sec int, err error) {
+	parts := strings.Split(clock, ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("whTimer: invalid time of day %q: want HH:MM or HH:MM:SS", clock)
+	}
+
+	limits := [...]struct {
+		name string
+		max  int
+	}{{"hour", 23}, {"minute", 59}, {"second", 59}}
+	var values [3]int
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil || v < 0 || v > limits[i].max {
+			return 0, 0, 0, fmt.Errorf("whTimer: invalid time of day %q: %s must be 0-%d", clock, limits[i].name, limits[i].max)
+		}
+		values[i] = v
+	}
+	return values[0], values[1], values[2], nil
+}
+
+// DailyAt 每天在 loc 时区的 clock 时刻执行，clock 格式为 "09:30" 或 "09:30:15"
+// loc 为 nil 时使用本地时区
+func (t *Timer) DailyAt(clock string, loc *time.Location, callback func()) (*CronEntry, error) {
+	return t.clockAt(clock, loc, 0, false, callback)
+}
+
+// WeeklyAt 每周 weekday 在 loc 时区的 clock 时刻执行
+func (t *Timer) WeeklyAt(weekday time.Weekday, clock string, loc *time.Location, callback func()) (*CronEntry, error) {
+	if weekday < time.Sunday || weekday > time.Saturday {
+		return nil, fmt.Errorf("whTimer: invalid weekday %d", weekday)
+	}
+	return t.clockAt(clock, loc, weekday, true, callback)
+}
+
+func (t *Timer) clockAt(clock string, loc *time.Location, weekday time.Weekday, weekly bool, callback func()) (*CronEntry, error) {
+	hour, min, sec, err := parseClock(clock)
+	if err != nil {
+		return nil, err
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	spec := fmt.Sprintf("%d %d %d * * *", sec, min, hour)
+	if weekly {
+		spec = fmt.Sprintf("%d %d %d * * %d", sec, min, hour, weekday)
+	}
+	if loc != time.Local {
+		zone, err := cronZone(loc)
+		if err != nil {
+			return nil, err
+		}
+		spec = "CRON_TZ=" + zone + " " + spec
+	}
+	return t.addSchedule(spec, &clockSchedule{
+		hour:    hour,
+		min:     min,
+		sec:     sec,
+		weekday: weekday,
+		weekly:  weekly,
+		loc:     loc,
+	}, callback), nil
+}
+
+// cronZone 返回可被 CRON_TZ 重新解析的时区名，保证 Spec 导出后能还原同一时区
+// 无法按名称加载的固定偏移时区（如 time.FixedZone）映射为整小时的 Etc/GMT±N，其余返回错误
+func cronZone(loc *time.Location) (string, error) {
+	// LoadLocation 把 "" 当作 UTC、"Local" 当作本地时区，均不代表 loc 本身
+	if name := loc.String(); name != "" && name != "Local" {
+		if _, err := time.LoadLocation(name); err == nil {
+			return name, nil
+		}
+	}
+	year := time.Now().Year()
+	_, winter := time.Date(year, time.January, 1, 0, 0, 0, 0, loc).Zone()
+	_, summer := time.Date(year, time.July, 1, 0, 0, 0, 0, loc).Zone()
+	if winter == summer && winter%3600 == 0 && winter >= -12*3600 && winter <= 14*3600 {
+		// Etc/GMT 区名的符号与 UTC 偏移相反：Etc/GMT-8 即 UTC+8
+		switch hours := winter / 3600; {
+		case hours == 0:
+			return "UTC", nil
+		case hours > 0:
+			return "Etc/GMT-" + strconv.Itoa(hours), nil
+		default:
+			return "Etc/GMT+" + strconv.Itoa(-hours), nil
+		}
+	}
+	return "", fmt.Errorf("whTimer: location %q cannot be expressed as CRON_TZ", loc)
+}
@@ -0,0 +1,103 @@
+package whTimer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClockSchedule(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	s := &clockSchedule{hour: 9, min: 30, loc: loc}
+
+	base := time.Date(2025, 3, 10, 8, 0, 0, 0, loc) // 周一
+	if next := s.Next(base); !next.Equal(time.Date(2025, 3, 10, 9, 30, 0, 0, loc)) {
+		t.Fatalf("daily next=%v", next)
+	}
+	if next := s.Next(time.Date(2025, 3, 10, 9, 30, 0, 0, loc)); !next.Equal(time.Date(2025, 3, 11, 9, 30, 0, 0, loc)) {
+		t.Fatalf("daily next after exact=%v", next)
+	}
+
+	s.weekly, s.weekday = true, time.Friday
+	if next := s.Next(base); !next.Equal(time.Date(2025, 3, 14, 9, 30, 0, 0, loc)) {
+		t.Fatalf("weekly next=%v", next)
+	}
+	s.weekday = time.Monday
+	if next := s.Next(time.Date(2025, 3, 10, 10, 0, 0, 0, loc)); !next.Equal(time.Date(2025, 3, 17, 9, 30, 0, 0, loc)) {
+		t.Fatalf("weekly next week=%v", next)
+	}
+}
+
+func TestDailyAtInvalid(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	for _, clock := range []string{"", "9", "24:00", "09:60", "09:30:61", "aa:bb"} {
+		if _, err := timer.DailyAt(clock, nil, func() {}); err == nil {
+			t.Errorf("DailyAt(%q) accepted", clock)
+		}
+	}
+	if _, err := timer.WeeklyAt(7, "09:30", nil, func() {}); err == nil {
+		t.Error("WeeklyAt accepted invalid weekday")
+	}
+	if _, err := timer.DailyAt("09:30:15", time.UTC, func() {}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDailyAtFixedZone(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	for _, tc := range []struct {
+		loc  *time.Location
+		spec string
+	}{
+		{time.FixedZone("UTC+8", 8*3600), "CRON_TZ=Etc/GMT-8 0 30 9 * * *"},
+		{time.FixedZone("", -5*3600), "CRON_TZ=Etc/GMT+5 0 30 9 * * *"},
+		{time.FixedZone("Z", 0), "CRON_TZ=UTC 0 30 9 * * *"},
+		{time.UTC, "CRON_TZ=UTC 0 30 9 * * *"},
+	} {
+		c, err := timer.DailyAt("09:30", tc.loc, func() {})
+		if err != nil {
+			t.Fatalf("%v: %v", tc.loc, err)
+		}
+		if c.Spec() != tc.spec {
+			t.Errorf("%v: spec=%q, want %q", tc.loc, c.Spec(), tc.spec)
+		}
+		if _, err := time.LoadLocation(strings.TrimPrefix(strings.Fields(c.Spec())[0], "CRON_TZ=")); err != nil {
+			t.Errorf("%v: spec zone not reparseable: %v", tc.loc, err)
+		}
+	}
+	if _, err := timer.DailyAt("09:30", time.FixedZone("IST", 5*3600+1800), func() {}); err == nil {
+		t.Error("DailyAt accepted a zone CRON_TZ cannot express")
+	}
+}
+
+func TestScheduleBuilder(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	s, err := timer.Every(5*time.Minute).Between(9, 17).OnWeekdays().In(time.UTC).Schedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct{ at, want time.Time }{
+		// 周一窗口前 → 窗口起点
+		{time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC), time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)},
+		// 窗口内 → 下一个对齐点
+		{time.Date(2025, 3, 10, 9, 3, 0, 0, time.UTC), time.Date(2025, 3, 10, 9, 5, 0, 0, time.UTC)},
+		{time.Date(2025, 3, 10, 9, 5, 0, 0, time.UTC), time.Date(2025, 3, 10, 9, 10, 0, 0, time.UTC)},
+		// 窗口最后一个点之后 → 次日
+		{time.Date(2025, 3, 10, 16, 55, 0, 0, time.UTC), time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC)},
+		// 周五晚 → 下周一
+		{time.Date(2025, 3, 14, 18, 0, 0, 0, time.UTC), time.Date(2025, 3, 17, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		if got := s.Next(c.at); !got.Equal(c.want) {
+			t.Errorf("Next(%v)=%v, want %v", c.at, got, c.want)
+		}
+	}
+
+	if _, err := timer.Every(0).Do(func() {}); err == nil {
+		t.Error("zero interval accepted")
+	}
+	if _, err := timer.Every(time.Minute).Between(17, 9).Do(func() {}); err == nil {
+		t.Error("inverted window accepted")
+	}
+}
@@ -0,0 +1,16 @@
+package whTimer
+
+// CancelWhere 取消所有满足 pred 的未触发条目（包括仍在队列中的），返回取消数量
+// pred 在 run loop 中执行，不应阻塞或再调用本定时器的同步方法；
+// 可用于紧急降载，例如取消所有 24 小时以后才到期的任务
+func (t *Timer) CancelWhere(pred func(*Entry) bool) int {
+	n := 0
+	t.inspect(func() {
+		t.walkPending(func(e *Entry) {
+			if !e.IsDone() && pred(e) && e.Cancel() {
+				n++
+			}
+		})
+	})
+	return n
+}
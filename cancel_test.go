@@ -0,0 +1,31 @@
+package whTimer
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimerCancelWhere(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	var fired []string
+	for _, d := range []time.Duration{time.Hour, 25 * time.Hour, 48 * time.Hour} {
+		timer.AddTaggedEntry(d.String(), d, func() { fired = append(fired, d.String()) })
+	}
+	timer.Advance(base)
+	// 仍在队列中的条目同样参与判断
+	timer.AddEntry(72*time.Hour, func() { fired = append(fired, "queued") })
+
+	cutoff := base.Add(24 * time.Hour)
+	n := timer.CancelWhere(func(e *Entry) bool { return e.ExpireAt().After(cutoff) })
+	if n != 3 {
+		t.Fatalf("canceled %d, want 3", n)
+	}
+	timer.Advance(base.Add(100 * time.Hour))
+	if !slices.Equal(fired, []string{"1h0m0s"}) {
+		t.Fatalf("fired=%v", fired)
+	}
+}
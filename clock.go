@@ -0,0 +1,102 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// virtualClock 虚拟时间快照，修改时整体替换以保证无锁读取
+// 虚拟时间 = anchorVirtual + (墙钟 - anchorWall) × scale，暂停时停在 anchorVirtual
+type virtualClock struct {
+	anchorWall    time.Time
+	anchorVirtual time.Time
+	scale         float64
+	paused        bool
+}
+
+func (c *virtualClock) at(wall time.Time) time.Time {
+	if c.paused {
+		return c.anchorVirtual
+	}
+	return c.anchorVirtual.Add(time.Duration(float64(wall.Sub(c.anchorWall)) * c.scale))
+}
+
+// toWall 将虚拟时间换算为墙钟时间，暂停时返回 false
+func (c *virtualClock) toWall(virtual time.Time) (time.Time, bool) {
+	if c.paused {
+		return time.Time{}, false
+	}
+	return c.anchorWall.Add(time.Duration(float64(virtual.Sub(c.anchorVirtual)) / c.scale)), true
+}
+
+// now 返回定时器当前时间
+// 手动驱动模式下为最近一次 Advance 的时间，启用虚拟时间后为缩放后的时间
+func (t *Timer) now() time.Time {
+	if n := t.manualNow.Load(); n != 0 {
+		return time.Unix(0, n)
+	}
+	if c := t.clock.Load(); c != nil {
+		return c.at(time.Now())
+	}
+	return time.Now()
+}
+
+// updateClock 以当前时刻为锚点替换虚拟时钟并唤醒 run loop 重新计算
+func (t *Timer) updateClock(fn func(c *virtualClock)) {
+	for {
+		old := t.clock.Load()
+		wall := time.Now()
+		c := &virtualClock{anchorWall: wall, anchorVirtual: wall, scale: 1}
+		if old != nil {
+			c.anchorVirtual = old.at(wall)
+			c.scale = old.scale
+			c.paused = old.paused
+		}
+		fn(c)
+		if t.clock.CompareAndSwap(old, c) {
+			break
+		}
+	}
+	select {
+	case t.wakeChan <- struct{}{}:
+	default:
+	}
+}
+
+// SetTimeScale 设置时间流速，2 表示两倍速，0.5 表示半速
+// 所有已调度任务按虚拟时间触发，随流速一同拉伸或压缩
+func (t *Timer) SetTimeScale(scale float64) {
+	if scale <= 0 {
+		panic("whTimer: time scale must be positive")
+	}
+	t.updateClock(func(c *virtualClock) {
+		c.scale = scale
+	})
+}
+
+// TimeScale 获取当前时间流速
+func (t *Timer) TimeScale() float64 {
+	if c := t.clock.Load(); c != nil {
+		return c.scale
+	}
+	return 1
+}
+
+// Pause 冻结虚拟时间，暂停期间不会触发任何任务
+func (t *Timer) Pause() {
+	t.updateClock(func(c *virtualClock) {
+		c.paused = true
+	})
+}
+
+// Resume 恢复虚拟时间流动
+func (t *Timer) Resume() {
+	t.updateClock(func(c *virtualClock) {
+		c.paused = false
+	})
+}
+
+// Paused 检查虚拟时间是否已暂停
+func (t *Timer) Paused() bool {
+	c := t.clock.Load()
+	return c != nil && c.paused
+}
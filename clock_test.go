@@ -0,0 +1,41 @@
+package whTimer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimerTimeScale(t *testing.T) {
+	var executed atomic.Int32
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	})
+	timer.Start()
+	defer timer.Stop()
+
+	// 十倍速下 500ms 的虚拟延迟约 50ms 触发
+	timer.SetTimeScale(10)
+	timer.AddEntry(500*time.Millisecond, func() {
+		executed.Add(1)
+	})
+	time.Sleep(100 * time.Millisecond)
+	if executed.Load() != 1 {
+		t.Fatalf("expected scaled entry to fire, got %d", executed.Load())
+	}
+
+	// 暂停期间不触发，恢复后继续
+	timer.Pause()
+	timer.AddEntry(100*time.Millisecond, func() {
+		executed.Add(1)
+	})
+	time.Sleep(60 * time.Millisecond)
+	if executed.Load() != 1 {
+		t.Errorf("entry fired while paused")
+	}
+	timer.Resume()
+	time.Sleep(60 * time.Millisecond)
+	if executed.Load() != 2 {
+		t.Errorf("expected entry to fire after resume, got %d", executed.Load())
+	}
+}
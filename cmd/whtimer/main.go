@@ -0,0 +1,99 @@
+// whtimer whtimerd 的命令行客户端
+//
+//	whtimer add <延迟|RFC3339 时间> <url> [body]   添加一次性任务
+//	whtimer cron <表达式> <url> [body]             添加周期任务（秒级 Cron）
+//	whtimer cancel <id>                           取消任务
+//	whtimer ls                                    列出任务
+//	whtimer next                                  显示下一个将触发的任务
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"whTimer/daemon"
+)
+
+func main() {
+	addr := os.Getenv("WHTIMER_ADDR")
+	if addr == "" {
+		addr = "http://127.0.0.1:7070"
+	}
+	flag.StringVar(&addr, "addr", addr, "whtimerd 地址，默认取 WHTIMER_ADDR")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: whtimer [-addr url] add|cron|cancel|ls|next [args]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	client := &daemon.Client{BaseURL: addr}
+	if err := run(client, flag.Args(), os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "whtimer:", err)
+		os.Exit(1)
+	}
+}
+
+func run(c *daemon.Client, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		flag.Usage()
+		return errors.New("missing command")
+	}
+
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "add", "cron":
+		if len(args) < 2 || len(args) > 3 {
+			return fmt.Errorf("usage: whtimer %s <when> <url> [body]", cmd)
+		}
+		req := daemon.CreateRequest{Target: daemon.Target{URL: args[1]}}
+		if len(args) == 3 {
+			req.Target.Body = args[2]
+		}
+		switch {
+		case cmd == "cron":
+			req.Cron = args[0]
+		default:
+			if at, err := time.Parse(time.RFC3339, args[0]); err == nil {
+				req.At = at
+			} else {
+				req.Delay = args[0]
+			}
+		}
+		job, err := c.Create(req)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s\t%s\n", job.ID, job.Next.Format(time.RFC3339))
+	case "cancel":
+		if len(args) != 1 {
+			return errors.New("usage: whtimer cancel <id>")
+		}
+		return c.Cancel(args[0])
+	case "ls", "next":
+		jobs, err := c.List()
+		if err != nil {
+			return err
+		}
+		if cmd == "next" && len(jobs) > 1 {
+			jobs = jobs[:1]
+		}
+		w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNEXT\tSCHEDULE\tURL")
+		for _, j := range jobs {
+			schedule := j.Cron
+			if schedule == "" {
+				schedule = "once"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", j.ID, j.Next.Format(time.RFC3339), schedule, j.Target.URL)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"whTimer"
+	"whTimer/daemon"
+)
+
+func TestRun(t *testing.T) {
+	timer := whTimer.NewTimer(func(e *whTimer.Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+	svc, _ := daemon.NewService(timer, "")
+	api := httptest.NewServer(svc.Handler())
+	defer api.Close()
+	c := &daemon.Client{BaseURL: api.URL}
+
+	var out bytes.Buffer
+	for _, args := range [][]string{
+		{"add", "1h", "http://127.0.0.1:1/a"},
+		{"add", "2099-01-01T00:00:00Z", "http://127.0.0.1:1/b", "payload"},
+		{"cron", "0 0 0 1 1 *", "http://127.0.0.1:1/c"},
+	} {
+		if err := run(c, args, &out); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+	}
+
+	out.Reset()
+	if err := run(c, []string{"next"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) != 2 || !strings.Contains(lines[1], "/a") {
+		t.Fatalf("next:\n%s", out.String())
+	}
+
+	if err := run(c, []string{"cancel", "1"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(c, []string{"cancel", "1"}, &out); err != daemon.ErrNotFound {
+		t.Fatalf("second cancel: %v", err)
+	}
+
+	out.Reset()
+	if err := run(c, []string{"ls"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(out.String(), "\n"); n != 3 {
+		t.Fatalf("ls:\n%s", out.String())
+	}
+
+	for _, args := range [][]string{{"bogus"}, {"add", "1h"}, {"add", "soon", "http://x"}} {
+		if err := run(c, args, &out); err == nil {
+			t.Errorf("%v accepted", args)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+//go:generate protoc --go_out=pb --go_opt=paths=source_relative --go-grpc_out=pb --go-grpc_opt=paths=source_relative whtimerd.proto
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"whTimer/cmd/whtimerd/pb"
+	"whTimer/daemon"
+)
+
+// grpcServer 将 whtimerd.proto 定义的 Scheduler 服务映射到 daemon.Service
+type grpcServer struct {
+	pb.UnimplementedSchedulerServer
+	svc *daemon.Service
+}
+
+func (s *grpcServer) ScheduleAt(_ context.Context, req *pb.ScheduleAtRequest) (*pb.Job, error) {
+	if req.GetAt() == nil {
+		return nil, status.Error(codes.InvalidArgument, "at is required")
+	}
+	j, err := s.svc.ScheduleAt(req.GetAt().AsTime(), fromTarget(req.GetTarget()))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toJob(j), nil
+}
+
+func (s *grpcServer) ScheduleCron(_ context.Context, req *pb.ScheduleCronRequest) (*pb.Job, error) {
+	j, err := s.svc.ScheduleCron(req.GetCron(), fromTarget(req.GetTarget()))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toJob(j), nil
+}
+
+func (s *grpcServer) Cancel(_ context.Context, req *pb.CancelRequest) (*pb.CancelResponse, error) {
+	if err := s.svc.Cancel(req.GetId()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.CancelResponse{}, nil
+}
+
+func (s *grpcServer) List(context.Context, *pb.ListRequest) (*pb.ListResponse, error) {
+	jobs := s.svc.List()
+	resp := &pb.ListResponse{Jobs: make([]*pb.Job, len(jobs))}
+	for i, j := range jobs {
+		resp.Jobs[i] = toJob(j)
+	}
+	return resp, nil
+}
+
+// grpcError 按 daemon 的错误类别映射状态码，其余均视为请求参数错误
+func grpcError(err error) error {
+	switch {
+	case errors.Is(err, daemon.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, daemon.ErrPersist):
+		return status.Error(codes.Internal, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func fromTarget(t *pb.Target) daemon.Target {
+	return daemon.Target{URL: t.GetUrl(), Method: t.GetMethod(), Headers: t.GetHeaders(), Body: t.GetBody()}
+}
+
+func toJob(j daemon.Job) *pb.Job {
+	out := &pb.Job{
+		Id:   j.ID,
+		Cron: j.Cron,
+		Target: &pb.Target{
+			Url:     j.Target.URL,
+			Method:  j.Target.Method,
+			Headers: j.Target.Headers,
+			Body:    j.Target.Body,
+		},
+	}
+	if !j.At.IsZero() {
+		out.At = timestamppb.New(j.At)
+	}
+	if !j.Next.IsZero() {
+		out.Next = timestamppb.New(j.Next)
+	}
+	return out
+}
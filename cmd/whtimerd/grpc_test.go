@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"whTimer"
+	"whTimer/cmd/whtimerd/pb"
+	"whTimer/daemon"
+)
+
+func TestGRPCServer(t *testing.T) {
+	timer := whTimer.NewTimer(func(e *whTimer.Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	svc, _ := daemon.NewService(timer, "")
+	lis := bufconn.Listen(1 << 16)
+	srv := grpc.NewServer()
+	pb.RegisterSchedulerServer(srv, &grpcServer{svc: svc})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSchedulerClient(conn)
+	ctx := context.Background()
+	target := &pb.Target{Url: "http://127.0.0.1:1/hook"}
+
+	at := time.Now().Add(time.Hour)
+	job, err := client.ScheduleAt(ctx, &pb.ScheduleAtRequest{At: timestamppb.New(at), Target: target})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.GetId() == "" || !job.GetAt().AsTime().Equal(at) || job.GetTarget().GetUrl() != target.Url {
+		t.Fatalf("ScheduleAt=%v", job)
+	}
+	if _, err := client.ScheduleCron(ctx, &pb.ScheduleCronRequest{Cron: "bad", Target: target}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("bad cron: %v", err)
+	}
+	if _, err := client.ScheduleAt(ctx, &pb.ScheduleAtRequest{Target: target}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("missing at: %v", err)
+	}
+
+	list, err := client.List(ctx, &pb.ListRequest{})
+	if err != nil || len(list.GetJobs()) != 1 || list.GetJobs()[0].GetNext() == nil {
+		t.Fatalf("List=%v err=%v", list, err)
+	}
+
+	if _, err := client.Cancel(ctx, &pb.CancelRequest{Id: job.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Cancel(ctx, &pb.CancelRequest{Id: job.GetId()}); status.Code(err) != codes.NotFound {
+		t.Fatalf("second cancel: %v", err)
+	}
+}
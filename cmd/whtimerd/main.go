@@ -0,0 +1,91 @@
+// whtimerd 独立延迟任务服务，任务到期时以 HTTP 回调通知调用方
+//
+// HTTP JSON API 监听 -http 地址，接口见 daemon.Service.Handler；
+// gRPC 接口定义见 whtimerd.proto（桩代码位于 pb 包），监听 -grpc 地址
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+
+	"whTimer"
+	"whTimer/cmd/whtimerd/pb"
+	"whTimer/daemon"
+)
+
+func main() {
+	data := flag.String("data", "whtimerd.json", "任务持久化文件")
+	httpAddr := flag.String("http", "127.0.0.1:7070", "HTTP JSON API 监听地址，为空时不启用")
+	grpcAddr := flag.String("grpc", "", "gRPC 监听地址，为空时不启用")
+	flag.Parse()
+
+	if err := run(*data, *httpAddr, *grpcAddr); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run 启动服务直至收到退出信号或某个监听失败，返回前停止定时器与所有监听
+func run(data, httpAddr, grpcAddr string) error {
+	timer := whTimer.NewTimer(func(e *whTimer.Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	svc, err := daemon.NewService(timer, data)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// 先停止定时器，不再有任务触发，再写回最后的变更
+		timer.Stop()
+		if err := svc.Close(); err != nil {
+			log.Printf("whtimerd: save state: %v", err)
+		}
+	}()
+	log.Printf("whtimerd: restored %d jobs from %s", len(svc.List()), data)
+
+	serveErr := make(chan error, 2)
+
+	if grpcAddr != "" {
+		lis, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			return err
+		}
+		srv := grpc.NewServer()
+		pb.RegisterSchedulerServer(srv, &grpcServer{svc: svc})
+		go func() { serveErr <- srv.Serve(lis) }()
+		defer srv.GracefulStop()
+		log.Printf("whtimerd: gRPC API listening on %s", lis.Addr())
+	}
+
+	if httpAddr != "" {
+		lis, err := net.Listen("tcp", httpAddr)
+		if err != nil {
+			return err
+		}
+		srv := &http.Server{Handler: svc.Handler()}
+		go func() {
+			if err := srv.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+				serveErr <- err
+			}
+		}()
+		defer srv.Close()
+		log.Printf("whtimerd: HTTP API listening on %s", lis.Addr())
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	select {
+	case <-sig:
+		return nil
+	case err := <-serveErr:
+		return err
+	}
+}
@@ -0,0 +1,544 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: whtimerd.proto
+
+// whtimerd 延迟任务服务的 gRPC 接口，语义与 daemon.Service 一一对应
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Target struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Method        string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Headers       map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Target) Reset() {
+	*x = Target{}
+	mi := &file_whtimerd_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Target) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Target) ProtoMessage() {}
+
+func (x *Target) ProtoReflect() protoreflect.Message {
+	mi := &file_whtimerd_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Target.ProtoReflect.Descriptor instead.
+func (*Target) Descriptor() ([]byte, []int) {
+	return file_whtimerd_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Target) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Target) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *Target) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *Target) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type Job struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"`
+	Cron          string                 `protobuf:"bytes,3,opt,name=cron,proto3" json:"cron,omitempty"`
+	Target        *Target                `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	Next          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=next,proto3" json:"next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_whtimerd_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_whtimerd_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_whtimerd_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Job) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Job) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *Job) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *Job) GetTarget() *Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *Job) GetNext() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Next
+	}
+	return nil
+}
+
+type ScheduleAtRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=at,proto3" json:"at,omitempty"`
+	Target        *Target                `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleAtRequest) Reset() {
+	*x = ScheduleAtRequest{}
+	mi := &file_whtimerd_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleAtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleAtRequest) ProtoMessage() {}
+
+func (x *ScheduleAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whtimerd_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleAtRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleAtRequest) Descriptor() ([]byte, []int) {
+	return file_whtimerd_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ScheduleAtRequest) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *ScheduleAtRequest) GetTarget() *Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+type ScheduleCronRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cron          string                 `protobuf:"bytes,1,opt,name=cron,proto3" json:"cron,omitempty"`
+	Target        *Target                `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleCronRequest) Reset() {
+	*x = ScheduleCronRequest{}
+	mi := &file_whtimerd_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleCronRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleCronRequest) ProtoMessage() {}
+
+func (x *ScheduleCronRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whtimerd_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleCronRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleCronRequest) Descriptor() ([]byte, []int) {
+	return file_whtimerd_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ScheduleCronRequest) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *ScheduleCronRequest) GetTarget() *Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+type CancelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelRequest) Reset() {
+	*x = CancelRequest{}
+	mi := &file_whtimerd_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRequest) ProtoMessage() {}
+
+func (x *CancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whtimerd_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRequest.ProtoReflect.Descriptor instead.
+func (*CancelRequest) Descriptor() ([]byte, []int) {
+	return file_whtimerd_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CancelRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelResponse) Reset() {
+	*x = CancelResponse{}
+	mi := &file_whtimerd_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelResponse) ProtoMessage() {}
+
+func (x *CancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_whtimerd_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelResponse.ProtoReflect.Descriptor instead.
+func (*CancelResponse) Descriptor() ([]byte, []int) {
+	return file_whtimerd_proto_rawDescGZIP(), []int{5}
+}
+
+type ListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_whtimerd_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whtimerd_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_whtimerd_proto_rawDescGZIP(), []int{6}
+}
+
+type ListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_whtimerd_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_whtimerd_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_whtimerd_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+var File_whtimerd_proto protoreflect.FileDescriptor
+
+const file_whtimerd_proto_rawDesc = "" +
+	"\n" +
+	"\x0ewhtimerd.proto\x12\vwhtimerd.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbe\x01\n" +
+	"\x06Target\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12:\n" +
+	"\aheaders\x18\x03 \x03(\v2 .whtimerd.v1.Target.HeadersEntryR\aheaders\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb2\x01\n" +
+	"\x03Job\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
+	"\x02at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x12\n" +
+	"\x04cron\x18\x03 \x01(\tR\x04cron\x12+\n" +
+	"\x06target\x18\x04 \x01(\v2\x13.whtimerd.v1.TargetR\x06target\x12.\n" +
+	"\x04next\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04next\"l\n" +
+	"\x11ScheduleAtRequest\x12*\n" +
+	"\x02at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12+\n" +
+	"\x06target\x18\x02 \x01(\v2\x13.whtimerd.v1.TargetR\x06target\"V\n" +
+	"\x13ScheduleCronRequest\x12\x12\n" +
+	"\x04cron\x18\x01 \x01(\tR\x04cron\x12+\n" +
+	"\x06target\x18\x02 \x01(\v2\x13.whtimerd.v1.TargetR\x06target\"\x1f\n" +
+	"\rCancelRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x10\n" +
+	"\x0eCancelResponse\"\r\n" +
+	"\vListRequest\"4\n" +
+	"\fListResponse\x12$\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x10.whtimerd.v1.JobR\x04jobs2\x8f\x02\n" +
+	"\tScheduler\x12>\n" +
+	"\n" +
+	"ScheduleAt\x12\x1e.whtimerd.v1.ScheduleAtRequest\x1a\x10.whtimerd.v1.Job\x12B\n" +
+	"\fScheduleCron\x12 .whtimerd.v1.ScheduleCronRequest\x1a\x10.whtimerd.v1.Job\x12A\n" +
+	"\x06Cancel\x12\x1a.whtimerd.v1.CancelRequest\x1a\x1b.whtimerd.v1.CancelResponse\x12;\n" +
+	"\x04List\x12\x18.whtimerd.v1.ListRequest\x1a\x19.whtimerd.v1.ListResponseB\x19Z\x17whTimer/cmd/whtimerd/pbb\x06proto3"
+
+var (
+	file_whtimerd_proto_rawDescOnce sync.Once
+	file_whtimerd_proto_rawDescData []byte
+)
+
+func file_whtimerd_proto_rawDescGZIP() []byte {
+	file_whtimerd_proto_rawDescOnce.Do(func() {
+		file_whtimerd_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_whtimerd_proto_rawDesc), len(file_whtimerd_proto_rawDesc)))
+	})
+	return file_whtimerd_proto_rawDescData
+}
+
+var file_whtimerd_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_whtimerd_proto_goTypes = []any{
+	(*Target)(nil),                // 0: whtimerd.v1.Target
+	(*Job)(nil),                   // 1: whtimerd.v1.Job
+	(*ScheduleAtRequest)(nil),     // 2: whtimerd.v1.ScheduleAtRequest
+	(*ScheduleCronRequest)(nil),   // 3: whtimerd.v1.ScheduleCronRequest
+	(*CancelRequest)(nil),         // 4: whtimerd.v1.CancelRequest
+	(*CancelResponse)(nil),        // 5: whtimerd.v1.CancelResponse
+	(*ListRequest)(nil),           // 6: whtimerd.v1.ListRequest
+	(*ListResponse)(nil),          // 7: whtimerd.v1.ListResponse
+	nil,                           // 8: whtimerd.v1.Target.HeadersEntry
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+}
+var file_whtimerd_proto_depIdxs = []int32{
+	8,  // 0: whtimerd.v1.Target.headers:type_name -> whtimerd.v1.Target.HeadersEntry
+	9,  // 1: whtimerd.v1.Job.at:type_name -> google.protobuf.Timestamp
+	0,  // 2: whtimerd.v1.Job.target:type_name -> whtimerd.v1.Target
+	9,  // 3: whtimerd.v1.Job.next:type_name -> google.protobuf.Timestamp
+	9,  // 4: whtimerd.v1.ScheduleAtRequest.at:type_name -> google.protobuf.Timestamp
+	0,  // 5: whtimerd.v1.ScheduleAtRequest.target:type_name -> whtimerd.v1.Target
+	0,  // 6: whtimerd.v1.ScheduleCronRequest.target:type_name -> whtimerd.v1.Target
+	1,  // 7: whtimerd.v1.ListResponse.jobs:type_name -> whtimerd.v1.Job
+	2,  // 8: whtimerd.v1.Scheduler.ScheduleAt:input_type -> whtimerd.v1.ScheduleAtRequest
+	3,  // 9: whtimerd.v1.Scheduler.ScheduleCron:input_type -> whtimerd.v1.ScheduleCronRequest
+	4,  // 10: whtimerd.v1.Scheduler.Cancel:input_type -> whtimerd.v1.CancelRequest
+	6,  // 11: whtimerd.v1.Scheduler.List:input_type -> whtimerd.v1.ListRequest
+	1,  // 12: whtimerd.v1.Scheduler.ScheduleAt:output_type -> whtimerd.v1.Job
+	1,  // 13: whtimerd.v1.Scheduler.ScheduleCron:output_type -> whtimerd.v1.Job
+	5,  // 14: whtimerd.v1.Scheduler.Cancel:output_type -> whtimerd.v1.CancelResponse
+	7,  // 15: whtimerd.v1.Scheduler.List:output_type -> whtimerd.v1.ListResponse
+	12, // [12:16] is the sub-list for method output_type
+	8,  // [8:12] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_whtimerd_proto_init() }
+func file_whtimerd_proto_init() {
+	if File_whtimerd_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_whtimerd_proto_rawDesc), len(file_whtimerd_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_whtimerd_proto_goTypes,
+		DependencyIndexes: file_whtimerd_proto_depIdxs,
+		MessageInfos:      file_whtimerd_proto_msgTypes,
+	}.Build()
+	File_whtimerd_proto = out.File
+	file_whtimerd_proto_goTypes = nil
+	file_whtimerd_proto_depIdxs = nil
+}
@@ -0,0 +1,97 @@
+package whTimer
+
+import (
+	"sync/atomic"
+)
+
+// combinator AnyOf/AllOf 的共享状态
+type combinator struct {
+	t       *Timer
+	derived *Entry
+	inputs  []EntryRef
+	pending atomic.Int64 // 尚未结束的输入数
+	fired   atomic.Int64 // 已执行的输入数
+	decided atomic.Bool
+	anyOf   bool
+}
+
+// AnyOf 返回在任一输入条目执行后触发的派生条目，其余输入随即被取消 - 定时器层面的 select
+// 派生条目触发时经 handler 处理，与普通条目相同；所有输入均未执行就结束（取消或丢弃）时派生条目被取消
+// 输入条目由 handler 调用 Execute 且回调返回后才算执行，应在输入触发前调用
+func (t *Timer) AnyOf(callback func(), entries ...*Entry) *Entry {
+	return t.combine(true, callback, entries)
+}
+
+// AllOf 返回在所有输入条目均执行后触发的派生条目
+// 任一输入未执行就结束（取消或丢弃）时派生条目被取消；entries 为空时派生条目立即触发
+func (t *Timer) AllOf(callback func(), entries ...*Entry) *Entry {
+	return t.combine(false, callback, entries)
+}
+
+func (t *Timer) combine(anyOf bool, callback func(), entries []*Entry) *Entry {
+	// 派生条目在条件满足前不属于任何定时器，被取消时无人回收，因此不经过对象池
+	derived := newUnpooledEntry(t.now(), callback)
+	derived.owner = t
+
+	c := &combinator{t: t, derived: derived, anyOf: anyOf}
+	c.inputs = make([]EntryRef, len(entries))
+	for i, e := range entries {
+		c.inputs[i] = e.Ref()
+	}
+	c.pending.Store(int64(len(entries)) + 1)
+	for i, e := range entries {
+		ref := c.inputs[i]
+		if !e.watch(ref.gen, func() { c.done(ref) }) {
+			c.done(ref)
+		}
+	}
+	// 注册期间先结束的输入不会提前判定
+	c.finish()
+	return derived
+}
+
+// done 一个输入结束，条目在回收前通知，此时仍可读取其状态
+func (c *combinator) done(ref EntryRef) {
+	if ref.State() == StateFired {
+		c.fired.Add(1)
+		if c.anyOf {
+			c.decide(true)
+		}
+	} else if !c.anyOf {
+		c.decide(false)
+	}
+	c.finish()
+}
+
+// finish 所有输入均已结束时作出最终判定
+func (c *combinator) finish() {
+	if c.pending.Add(-1) != 0 {
+		return
+	}
+	if c.anyOf {
+		c.decide(c.fired.Load() > 0)
+	} else {
+		c.decide(c.fired.Load() == int64(len(c.inputs)))
+	}
+}
+
+// decide 至多生效一次：成立时将派生条目交给定时器立即触发，否则取消
+func (c *combinator) decide(ok bool) {
+	if !c.decided.CompareAndSwap(false, true) {
+		return
+	}
+	if !ok {
+		c.derived.Cancel()
+		return
+	}
+	if c.anyOf {
+		for _, ref := range c.inputs {
+			ref.Cancel()
+		}
+	}
+	if c.derived.IsDone() {
+		return
+	}
+	c.derived.expireAt = c.t.now()
+	c.t.push(c.derived)
+}
@@ -0,0 +1,63 @@
+package whTimer
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimerAnyOfAllOf(t *testing.T) {
+	var fired []string
+	timer := NewTimer(func(e *Entry) {
+		if !e.IsCanceled() {
+			fired = append(fired, e.Tag())
+		}
+		e.Execute()
+	})
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	soft := timer.AddTaggedEntry("soft", 10*time.Millisecond, func() {})
+	hard := timer.AddTaggedEntry("hard", 30*time.Millisecond, func() {})
+	hardRef := hard.Ref()
+	var anyRan bool
+	anyOf := timer.AnyOf(func() { anyRan = true }, soft, hard)
+	anyRef := anyOf.Ref()
+
+	a := timer.AddTaggedEntry("a", 10*time.Millisecond, func() {})
+	b := timer.AddTaggedEntry("b", 20*time.Millisecond, func() {})
+	var allRan bool
+	timer.AllOf(func() { allRan = true }, a, b)
+
+	c := timer.AddTaggedEntry("c", 10*time.Millisecond, func() {})
+	d := timer.AddTaggedEntry("d", 20*time.Millisecond, func() {})
+	failed := timer.AllOf(func() { t.Error("AllOf fired after an input was canceled") }, c, d)
+	failedRef := failed.Ref()
+	d.Cancel()
+
+	timer.Advance(base.Add(10 * time.Millisecond))
+	if !anyRan || hardRef.State() != StateCanceled || anyRef.State() != StateFired {
+		t.Fatalf("AnyOf ran=%v hard=%v derived=%v", anyRan, hardRef.State(), anyRef.State())
+	}
+	if allRan {
+		t.Fatal("AllOf fired before all inputs")
+	}
+	if failedRef.State() != StateCanceled {
+		t.Fatalf("failed AllOf state=%v", failedRef.State())
+	}
+
+	timer.Advance(base.Add(30 * time.Millisecond))
+	if !allRan {
+		t.Fatal("AllOf did not fire")
+	}
+	if !slices.Equal(fired, []string{"soft", "a", "c", "", "b", ""}) {
+		t.Fatalf("fired=%q", fired)
+	}
+
+	var empty bool
+	timer.AllOf(func() { empty = true })
+	timer.Advance(base.Add(40 * time.Millisecond))
+	if !empty {
+		t.Fatal("AllOf with no inputs did not fire")
+	}
+}
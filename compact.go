@@ -0,0 +1,162 @@
+package whTimer
+
+import (
+	"math/bits"
+	"time"
+)
+
+// CanceledStats 已取消但仍驻留在时间轮中的条目统计
+// 取消只修改条目状态，条目要等到期时才离开时间轮，长延迟任务被大量取消时会持续占用内存
+type CanceledStats struct {
+	Levels   [MaxLevel + 1]int // 按距到期时间所在的时间轮层级统计的已取消条目数
+	Canceled int
+	Total    int
+}
+
+// Ratio 返回已取消条目占比
+func (s CanceledStats) Ratio() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Canceled) / float64(s.Total)
+}
+
+// housekeeping WithAutoCompact 与 WithAutoReroot 的配置及下次检查时间，仅由 run loop 访问
+type housekeeping struct {
+	compactRatio float64
+	compactEvery time.Duration
+	nextCompact  time.Time
+	rerootEvery  time.Duration
+	nextReroot   time.Time
+}
+
+// WithAutoCompact 已取消条目占比达到 ratio 时自动压缩，默认关闭
+// run loop 每次醒来时至多每 interval 统计一次，统计需要遍历时间轮
+func WithAutoCompact(ratio float64, interval time.Duration) Option {
+	return func(t *Timer) {
+		t.housekeeping.compactRatio = ratio
+		t.housekeeping.compactEvery = interval
+	}
+}
+
+// levelOf 返回距到期 ms 毫秒的条目所在层级
+func levelOf(ms uint64) int {
+	if ms == 0 {
+		return 0
+	}
+	return min((bits.Len64(ms)-1)/SlotBits, MaxLevel)
+}
+
+// CanceledStats 统计已取消但仍驻留的条目
+func (t *Timer) CanceledStats() CanceledStats {
+	var s CanceledStats
+	t.inspect(func() {
+		s = t.canceledStats()
+	})
+	return s
+}
+
+func (t *Timer) canceledStats() CanceledStats {
+	var s CanceledStats
+	if t.wheel != nil {
+		t.wheel.Walk(func(e *Entry, ms uint64) bool {
+			s.Total++
+			if e.IsCanceled() {
+				s.Canceled++
+				s.Levels[levelOf(ms)]++
+			}
+			return true
+		})
+	}
+	now := t.now()
+	for _, e := range t.heap {
+		s.Total++
+		if e.IsCanceled() {
+			s.Canceled++
+			s.Levels[levelOf(ceilMs(max(e.expireAt.Sub(now), 0)))]++
+		}
+	}
+	return s
+}
+
+// Compact 立即将已取消的条目移出时间轮并回收，返回回收数量
+// 被压缩的条目不再交给 handler；在 handler 中调用时不执行，返回 0
+func (t *Timer) Compact() int {
+	var n int
+	t.inspect(func() {
+		if t.idle() {
+			n = t.compact()
+		}
+	})
+	return n
+}
+
+func (t *Timer) compact() int {
+	type resident struct {
+		entry *Entry
+		ms    uint64
+	}
+
+	// 遍历期间不能修改时间轮结构，先收集再移除
+	var canceled []resident
+	if t.wheel != nil {
+		t.wheel.Walk(func(e *Entry, ms uint64) bool {
+			if e.IsCanceled() {
+				canceled = append(canceled, resident{e, ms})
+			}
+			return true
+		})
+	}
+	for _, r := range canceled {
+		t.wheel.RemoveEntry(r.entry, r.ms)
+		t.numEntries--
+		t.release(r.entry)
+	}
+	if t.wheel != nil && t.wheel.Empty() {
+		t.wheel = nil
+		t.numEntries = 0
+	}
+	t.levelDownIfNeeded()
+
+	n := len(canceled)
+	if len(t.heap) > 0 {
+		kept := t.heap[:0]
+		for _, e := range t.heap {
+			if e.IsCanceled() {
+				t.release(e)
+				n++
+				continue
+			}
+			kept = append(kept, e)
+		}
+		clear(t.heap[len(kept):])
+		t.heap = kept
+		t.heap.init(t)
+	}
+	return n
+}
+
+// release 定时器放弃对已结束条目的引用
+func (t *Timer) release(e *Entry) {
+	if t.leaks != nil {
+		t.leaks.forget(e)
+	}
+	e.autoRelease()
+	e.detach()
+	e.tryRecycle()
+}
+
+// maybeCompact 由 run loop 调用，按 WithAutoCompact 的配置检查并压缩
+func (t *Timer) maybeCompact() {
+	if t.housekeeping.compactRatio <= 0 {
+		return
+	}
+	now := t.now()
+	if now.Before(t.housekeeping.nextCompact) {
+		return
+	}
+	t.housekeeping.nextCompact = now.Add(t.housekeeping.compactEvery)
+	if s := t.canceledStats(); s.Canceled > 0 && s.Ratio() >= t.housekeeping.compactRatio {
+		t.compact()
+	}
+}
@@ -0,0 +1,51 @@
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerCompact(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	var entries []*Entry
+	for i := range 10 {
+		entries = append(entries, timer.AddEntry(time.Duration(i+1)*time.Hour, func() {}))
+	}
+	keep := timer.AddEntry(10*time.Millisecond, func() {})
+	timer.Advance(base)
+	for _, e := range entries[:8] {
+		e.Cancel()
+	}
+
+	s := timer.CanceledStats()
+	// 1h 到 8h 介于 2^21 与 2^25 毫秒之间，分布在第 3、4 层
+	if s.Total != 11 || s.Canceled != 8 || s.Levels[3]+s.Levels[4] != 8 {
+		t.Fatalf("stats=%+v", s)
+	}
+	if n := timer.Compact(); n != 8 {
+		t.Fatalf("compacted %d, want 8", n)
+	}
+	if s := timer.CanceledStats(); s.Total != 3 || s.Canceled != 0 || timer.Pending() != 3 {
+		t.Fatalf("after compact stats=%+v pending=%d", s, timer.Pending())
+	}
+
+	ref := keep.Ref()
+	timer.Advance(base.Add(20 * time.Millisecond))
+	if ref.State() != StateFired && ref.State() != StateRecycled {
+		t.Fatalf("remaining entry not fired: %v", ref.State())
+	}
+
+	auto := NewTimer(func(e *Entry) { e.Execute() }, WithAutoCompact(0.5, time.Second), WithSmallHeap(8))
+	auto.Advance(base)
+	a := auto.AddEntry(time.Hour, func() {})
+	auto.AddEntry(time.Hour, func() {})
+	auto.Advance(base)
+	a.Cancel()
+	auto.Advance(base.Add(2 * time.Second))
+	if auto.Pending() != 1 {
+		t.Fatalf("auto compaction did not run, pending=%d", auto.Pending())
+	}
+}
@@ -0,0 +1,104 @@
+package whTimer
+
+import (
+	"sync/atomic"
+)
+
+// concurrencyGroup 同一标签回调的并发上限
+// running 与 waiting 由执行回调的 goroutine 与 run loop 共同访问，queue 仅由 run loop 访问
+type concurrencyGroup struct {
+	limit   int64
+	running atomic.Int64 // 已交给 handler、回调尚未结束的条目数
+	waiting atomic.Int64 // 排队等待空位的条目数
+	queue   []*Entry
+	head    int
+}
+
+// WithConcurrencyLimit 标签为 tag 的回调同时至多执行 limit 个，可多次设置不同标签，默认不限
+// 到期时已满的条目按到期顺序排队，有回调结束时依次交给 handler，保护共享的下游资源不被同时到期的任务压垮；
+// 回调从交给 handler 起占用空位，直到 Execute 或 Release 返回
+func WithConcurrencyLimit(tag string, limit int) Option {
+	return func(t *Timer) {
+		if t.groups == nil {
+			t.groups = make(map[string]*concurrencyGroup)
+		}
+		if limit <= 0 {
+			delete(t.groups, tag)
+			return
+		}
+		t.groups[tag] = &concurrencyGroup{limit: int64(limit)}
+	}
+}
+
+// tryAcquire 占用一个空位
+func (g *concurrencyGroup) tryAcquire() bool {
+	for {
+		n := g.running.Load()
+		if n >= g.limit {
+			return false
+		}
+		if g.running.CompareAndSwap(n, n+1) {
+			return true
+		}
+	}
+}
+
+// throttled 由 fire 调用，条目所在组已满时排队，返回是否已排队
+// 已有排队条目时新条目也必须排队以保持顺序
+func (t *Timer) throttled(entry *Entry) bool {
+	g := t.groups[entry.tag]
+	if g == nil || entry.IsDone() {
+		return false
+	}
+	// 先登记等待再尝试占位：回调结束时看不到等待者，说明占位一定能看到空出的位置
+	g.waiting.Add(1)
+	if len(g.queue) == g.head && g.tryAcquire() {
+		g.waiting.Add(-1)
+		entry.setFlag(flagSlot)
+		return false
+	}
+	g.queue = append(g.queue, entry)
+	return true
+}
+
+// fireWaiting 将有空位的排队条目交给 handler
+func (t *Timer) fireWaiting() {
+	for _, g := range t.groups {
+		for g.head < len(g.queue) {
+			entry := g.queue[g.head]
+			if !entry.IsDone() {
+				if !g.tryAcquire() {
+					break
+				}
+				entry.setFlag(flagSlot)
+			}
+			g.queue[g.head] = nil
+			g.head++
+			g.waiting.Add(-1)
+			t.deliver(entry)
+		}
+		if g.head == len(g.queue) {
+			g.queue = g.queue[:0]
+			g.head = 0
+		}
+	}
+}
+
+// releaseSlot 回调结束时归还占用的空位，有排队条目时唤醒 run loop
+func (e *Entry) releaseSlot() {
+	for {
+		v := e.state.Load()
+		if v&flagSlot == 0 {
+			return
+		}
+		if e.state.CompareAndSwap(v, v&^flagSlot) {
+			break
+		}
+	}
+	t := e.owner
+	g := t.groups[e.tag]
+	g.running.Add(-1)
+	if g.waiting.Load() > 0 {
+		t.wakeIfNeeded(true, e.expireAt)
+	}
+}
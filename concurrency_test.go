@@ -0,0 +1,78 @@
+package whTimer
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimerConcurrencyLimit(t *testing.T) {
+	var inFlight []*Entry
+	var order []int
+	timer := NewTimer(func(e *Entry) { inFlight = append(inFlight, e) }, WithConcurrencyLimit("db", 2))
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	for i := range 5 {
+		timer.AddTaggedEntry("db", time.Duration(i+1)*time.Millisecond, func() { order = append(order, i) })
+	}
+	timer.AddTaggedEntry("other", 10*time.Millisecond, func() {})
+	timer.Advance(base.Add(10 * time.Millisecond))
+	if len(inFlight) != 3 || inFlight[2].Tag() != "other" {
+		t.Fatalf("handed %d entries, want 2 db + 1 other", len(inFlight))
+	}
+	if p := timer.Pending(); p != 3 {
+		t.Fatalf("Pending=%d, want 3 waiting", p)
+	}
+
+	// 回调结束后空位依次交给排队的条目
+	inFlight[0].Execute()
+	inFlight[2].Execute()
+	timer.Advance(base.Add(10 * time.Millisecond))
+	if len(inFlight) != 4 {
+		t.Fatalf("handed %d entries after one slot freed", len(inFlight))
+	}
+	inFlight[1].Execute()
+	inFlight[3].Release()
+	timer.Advance(base.Add(10 * time.Millisecond))
+	inFlight[4].Execute()
+	inFlight[5].Execute()
+	timer.Advance(base.Add(10 * time.Millisecond))
+	if len(inFlight) != 6 || timer.Pending() != 0 {
+		t.Fatalf("handed=%d pending=%d", len(inFlight), timer.Pending())
+	}
+	if !slices.Equal(order, []int{0, 1, 3, 4}) {
+		t.Fatalf("order=%v", order)
+	}
+}
+
+func TestTimerConcurrencyLimitRunning(t *testing.T) {
+	const limit = 3
+	var running, peak atomic.Int64
+	timer := NewTimer(func(e *Entry) { go e.Execute() }, WithConcurrencyLimit("io", limit))
+	timer.Start()
+	defer timer.Stop()
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		timer.AddTaggedEntry("io", time.Millisecond, func() {
+			defer wg.Done()
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+		})
+	}
+	wg.Wait()
+	if p := peak.Load(); p > limit {
+		t.Fatalf("peak concurrency %d exceeds limit %d", p, limit)
+	}
+}
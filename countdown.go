@@ -0,0 +1,118 @@
+package whTimer
+
+import (
+	"sync"
+	"time"
+)
+
+// Countdown 可暂停的倒计时，适合配额窗口、考试计时与限流重置显示
+// 运行期间由一个时间轮条目负责到期，暂停时取消条目并记录剩余时长，恢复后按剩余时长重新添加
+type Countdown struct {
+	timer  *Timer
+	onDone func()
+
+	mu        sync.Mutex
+	entry     EntryRef
+	deadline  time.Time     // 运行中的到期时间
+	remaining time.Duration // 暂停时的剩余时长
+	paused    bool
+	done      bool
+}
+
+// NewCountdown 创建并立即开始倒计时，d 耗尽时执行 onDone（可为 nil）
+func (t *Timer) NewCountdown(d time.Duration, onDone func()) *Countdown {
+	c := &Countdown{timer: t, onDone: onDone}
+	c.mu.Lock()
+	c.arm(max(d, 0))
+	c.mu.Unlock()
+	return c
+}
+
+// arm 按剩余时长添加到期条目，调用方持有锁
+func (c *Countdown) arm(d time.Duration) {
+	c.deadline = c.timer.now().Add(d)
+	var ref EntryRef
+	_, ref = c.timer.addEntryRef(c.deadline, func() {
+		c.mu.Lock()
+		if c.entry != ref || c.done {
+			c.mu.Unlock()
+			return
+		}
+		c.done = true
+		c.mu.Unlock()
+		if c.onDone != nil {
+			c.onDone()
+		}
+	})
+	c.entry = ref
+}
+
+// Remaining 返回剩余时长，已结束时返回 0
+func (c *Countdown) Remaining() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case c.done:
+		return 0
+	case c.paused:
+		return c.remaining
+	default:
+		return max(c.deadline.Sub(c.timer.now()), 0)
+	}
+}
+
+// Pause 暂停倒计时，返回是否由运行转为暂停
+func (c *Countdown) Pause() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done || c.paused {
+		return false
+	}
+	if !c.entry.Cancel() {
+		// 到期条目已触发，完成回调即将执行
+		return false
+	}
+	c.paused = true
+	c.remaining = max(c.deadline.Sub(c.timer.now()), 0)
+	return true
+}
+
+// Resume 从暂停处继续倒计时，返回是否由暂停转为运行
+func (c *Countdown) Resume() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done || !c.paused {
+		return false
+	}
+	c.paused = false
+	c.arm(c.remaining)
+	return true
+}
+
+// Stop 终止倒计时且不执行完成回调，返回是否在完成前成功终止
+func (c *Countdown) Stop() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done {
+		return false
+	}
+	if !c.paused && !c.entry.Cancel() {
+		return false
+	}
+	c.done = true
+	return true
+}
+
+// Paused 检查是否处于暂停状态
+func (c *Countdown) Paused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
+}
+
+// Done 检查倒计时是否已结束（耗尽或被终止）
+func (c *Countdown) Done() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done
+}
@@ -0,0 +1,46 @@
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCountdown(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	var finished int
+	c := timer.NewCountdown(100*time.Millisecond, func() { finished++ })
+	timer.Advance(base.Add(30 * time.Millisecond))
+	if r := c.Remaining(); r != 70*time.Millisecond {
+		t.Fatalf("Remaining=%v", r)
+	}
+
+	if !c.Pause() || c.Pause() || !c.Paused() {
+		t.Fatal("Pause")
+	}
+	timer.Advance(base.Add(500 * time.Millisecond))
+	if finished != 0 || c.Remaining() != 70*time.Millisecond {
+		t.Fatalf("paused countdown advanced: finished=%d remaining=%v", finished, c.Remaining())
+	}
+
+	if !c.Resume() || c.Resume() {
+		t.Fatal("Resume")
+	}
+	timer.Advance(base.Add(560 * time.Millisecond))
+	if finished != 0 || c.Remaining() != 10*time.Millisecond {
+		t.Fatalf("finished=%d remaining=%v", finished, c.Remaining())
+	}
+	timer.Advance(base.Add(570 * time.Millisecond))
+	if finished != 1 || !c.Done() || c.Remaining() != 0 || c.Pause() || c.Stop() {
+		t.Fatalf("finished=%d done=%v", finished, c.Done())
+	}
+
+	stopped := timer.NewCountdown(time.Second, func() { t.Error("stopped countdown completed") })
+	stopped.Pause()
+	if !stopped.Stop() || stopped.Resume() {
+		t.Fatal("Stop while paused")
+	}
+	timer.Advance(base.Add(2 * time.Second))
+}
@@ -3,76 +3,189 @@ package whTimer
 import (
 	"sync/atomic"
 	"time"
-
-	"github.com/robfig/cron/v3"
 )
 
-// cron 表达式解析器 (支持秒级)
-var cronParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+// Schedule 周期调度规则，返回 t 之后的下一次触发时间，零值表示不再触发
+// 与 robfig/cron 的 cron.Schedule 兼容
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+// ScheduleFunc 函数形式的调度规则
+type ScheduleFunc func(t time.Time) time.Time
+
+// Next 实现 Schedule
+func (f ScheduleFunc) Next(t time.Time) time.Time {
+	return f(t)
+}
 
 // CronEntry 周期任务条目
 type CronEntry struct {
 	timer    *Timer
-	schedule cron.Schedule
+	spec     string // 可导出的规则描述，自定义规则为空
+	schedule Schedule
 	callback func()
-	entry    atomic.Pointer[Entry]
+	entry    atomic.Pointer[EntryRef]
+	entryID  atomic.Uint64 // 当前待触发条目的编号
+	next     atomic.Int64  // 下次触发时间（UnixNano）
 	stopped  atomic.Bool
+	period   atomic.Int64 // 负载自适应模式下的当前周期
+	failures atomic.Int32 // 连续失败次数
+	penalty  atomic.Int64 // 当前退避时长
+	ran      atomic.Int64 // 最近一次开始执行的发生的预定时间（UnixNano），供看门狗判断
+	watchdog atomic.Pointer[time.Timer]
 }
 
-// Cron 使用 Cron 表达式创建周期任务
-// 格式: "秒 分 时 日 月 星期"
-// 示例: "0 30 9 * * 1-5" 每周一到周五 9:30:00 执行
-func (t *Timer) Cron(expr string, callback func()) (*CronEntry, error) {
-	schedule, err := cronParser.Parse(expr)
-	if err != nil {
-		return nil, err
-	}
+// AddSchedule 按自定义调度规则创建周期任务
+func (t *Timer) AddSchedule(schedule Schedule, callback func()) *CronEntry {
+	return t.addSchedule("", schedule, callback)
+}
+
+func (t *Timer) addSchedule(spec string, schedule Schedule, callback func()) *CronEntry {
+	c := t.newCron(spec, callback)
+	c.schedule = schedule
+	c.scheduleNext()
+	return c
+}
 
+// newCron 创建周期任务并登记到定时器
+func (t *Timer) newCron(spec string, callback func()) *CronEntry {
 	c := &CronEntry{
 		timer:    t,
-		schedule: schedule,
+		spec:     spec,
 		callback: callback,
 	}
-	c.scheduleNext()
-	return c, nil
+	t.crons.Store(c, struct{}{})
+	return c
 }
 
 // CronAt 在指定时间执行一次
 func (t *Timer) CronAt(at time.Time, callback func()) *CronEntry {
-	c := &CronEntry{
-		timer:    t,
-		callback: callback,
-	}
-	entry := t.AddEntryAt(at, func() {
+	c := t.newCron("", callback)
+	c.arm(at, func() {
 		if !c.stopped.Load() {
+			c.finish()
 			callback()
 		}
 	})
-	c.entry.Store(entry)
 	return c
 }
 
-// CronInterval 按固定间隔执行
+// CatchUpPolicy 进程停顿跨越多个周期后 CronInterval 的补偿策略
+type CatchUpPolicy int
+
+const (
+	CatchUpOnce CatchUpPolicy = iota // 只补执行一次，下个周期从当前时刻重新计时（默认）
+	CatchUpAll                       // 每个错过的周期各执行一次
+	CatchUpSkip                      // 只补执行一次，之后跳到下一个对齐的周期点
+)
+
+// CronInterval 按固定间隔执行，停顿后的补偿策略为 CatchUpOnce
 func (t *Timer) CronInterval(interval time.Duration, callback func()) *CronEntry {
-	c := &CronEntry{
-		timer:    t,
-		callback: callback,
+	return t.CronIntervalPolicy(interval, CatchUpOnce, callback)
+}
+
+// CronIntervalPolicy 按固定间隔执行，并指定错过多个周期后的补偿策略
+func (t *Timer) CronIntervalPolicy(interval time.Duration, policy CatchUpPolicy, callback func()) *CronEntry {
+	c := t.newCron("@every "+interval.String(), callback)
+
+	next := t.now().Add(interval)
+	var fire func()
+	fire = func() {
+		if c.stopped.Load() {
+			return
+		}
+
+		now := t.now()
+		missed := 0
+		if late := now.Sub(next); late >= interval {
+			missed = int(late / interval)
+		}
+
+		runs := 1
+		switch policy {
+		case CatchUpAll:
+			runs += missed
+			next = next.Add(time.Duration(missed+1) * interval)
+		case CatchUpSkip:
+			next = next.Add(time.Duration(missed+1) * interval)
+		default:
+			next = now.Add(interval)
+		}
+
+		for i := 0; i < runs && !c.stopped.Load(); i++ {
+			callback()
+		}
+		if !c.stopped.Load() {
+			c.arm(next, fire)
+		}
 	}
+	c.arm(next, fire)
+	return c
+}
 
-	var scheduleNext func()
-	scheduleNext = func() {
+// CronIntervalAdaptive 负载自适应的固定间隔任务
+// 回调耗时超过当前周期的一半时将周期拉长到耗时的两倍（不超过 maxInterval），
+// 负载下降后每次回落 1/4 直至恢复 interval，避免周期任务在高负载下占满进程
+func (t *Timer) CronIntervalAdaptive(interval, maxInterval time.Duration, callback func()) *CronEntry {
+	c := t.newCron("@every "+interval.String(), callback)
+	maxInterval = max(maxInterval, interval)
+	c.period.Store(int64(interval))
+
+	var fire func()
+	fire = func() {
 		if c.stopped.Load() {
 			return
 		}
-		entry := t.AddEntry(interval, func() {
-			if !c.stopped.Load() {
-				callback()
-				scheduleNext()
-			}
-		})
-		c.entry.Store(entry)
+
+		start := t.now()
+		callback()
+		elapsed := t.now().Sub(start)
+
+		period := time.Duration(c.period.Load())
+		period = max(interval, 2*elapsed, period*3/4)
+		period = min(period, maxInterval)
+		c.period.Store(int64(period))
+
+		if !c.stopped.Load() {
+			c.arm(t.now().Add(period), fire)
+		}
 	}
-	scheduleNext()
+	c.arm(t.now().Add(interval), fire)
+	return c
+}
+
+// Period 返回负载自适应任务的当前周期，其他任务返回 0
+func (c *CronEntry) Period() time.Duration {
+	return time.Duration(c.period.Load())
+}
+
+// CronFunc 由 next 计算每次发生时间的周期任务，next 返回零值时停止
+// 适合交易日历、祈祷时间等应用自定义的重复规则
+func (t *Timer) CronFunc(next func(time.Time) time.Time, callback func()) *CronEntry {
+	return t.AddSchedule(ScheduleFunc(next), callback)
+}
+
+// AddAdaptive 创建自调度任务，首次立即执行，之后由回调返回值决定下次延迟
+// 回调返回 ok=false 时停止，适合由服务端决定退避时长的轮询
+func (t *Timer) AddAdaptive(fn func() (next time.Duration, ok bool)) *CronEntry {
+	c := t.newCron("", nil)
+
+	var run func()
+	run = func() {
+		if c.stopped.Load() {
+			return
+		}
+		next, ok := fn()
+		if !ok {
+			c.finish()
+			return
+		}
+		if !c.stopped.Load() {
+			c.arm(t.now().Add(next), run)
+		}
+	}
+	c.arm(t.now(), run)
 	return c
 }
 
@@ -81,22 +194,70 @@ func (c *CronEntry) scheduleNext() {
 		return
 	}
 
-	next := c.schedule.Next(time.Now())
-	entry := c.timer.AddEntryAt(next, func() {
+	next := c.schedule.Next(c.timer.now())
+	if next.IsZero() {
+		c.finish()
+		return
+	}
+	c.arm(next, func() {
 		if !c.stopped.Load() {
 			c.callback()
 			c.scheduleNext()
 		}
 	})
-	c.entry.Store(entry)
+}
+
+// arm 添加下一次触发的条目并记录其引用、编号与触发时间
+// 使用 EntryRef 避免取消已被回收复用的条目
+func (c *CronEntry) arm(at time.Time, fn func()) {
+	entry := c.timer.newEntry(at, c.watchRun(at, fn))
+	entry.delay = at.Sub(c.timer.now())
+	entry.hide()
+	ref := entry.Ref()
+	c.entryID.Store(entry.id)
+	c.next.Store(at.UnixNano())
+	c.entry.Store(&ref)
+	c.timer.push(entry)
+}
+
+// finish 标记结束并从定时器登记表中移除
+func (c *CronEntry) finish() {
+	c.stopped.Store(true)
+	c.timer.crons.Delete(c)
 }
 
 // Stop 停止周期任务
 func (c *CronEntry) Stop() {
-	c.stopped.Store(true)
+	c.finish()
 	if entry := c.entry.Load(); entry != nil {
 		entry.Cancel()
 	}
+	if watchdog := c.watchdog.Load(); watchdog != nil {
+		watchdog.Stop()
+	}
+}
+
+// Spec 返回规则描述: Cron 表达式、"@every 间隔"，自定义规则为空
+func (c *CronEntry) Spec() string {
+	return c.spec
+}
+
+// Next 返回下次触发时间，已停止时返回零值
+func (c *CronEntry) Next() time.Time {
+	if c.stopped.Load() {
+		return time.Time{}
+	}
+	return time.Unix(0, c.next.Load())
+}
+
+// Crons 返回定时器上所有未停止的周期任务
+func (t *Timer) Crons() []*CronEntry {
+	var crons []*CronEntry
+	t.crons.Range(func(k, _ any) bool {
+		crons = append(crons, k.(*CronEntry))
+		return true
+	})
+	return crons
 }
 
 // IsStopped 检查是否已停止
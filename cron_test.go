@@ -0,0 +1,145 @@
+package whTimer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCronIntervalCatchUp(t *testing.T) {
+	base := time.Now()
+	cases := []struct {
+		policy CatchUpPolicy
+		want   int32
+	}{
+		{CatchUpOnce, 1},
+		{CatchUpAll, 5},
+		{CatchUpSkip, 1},
+	}
+	for _, c := range cases {
+		var runs atomic.Int32
+		timer := NewTimer(func(e *Entry) { e.Execute() })
+		timer.Advance(base)
+		timer.CronIntervalPolicy(10*time.Millisecond, c.policy, func() { runs.Add(1) })
+
+		// 停顿 55ms，跨越 5 个周期
+		timer.Advance(base.Add(55 * time.Millisecond))
+		if runs.Load() != c.want {
+			t.Fatalf("policy %d: runs=%d, want %d", c.policy, runs.Load(), c.want)
+		}
+
+		// Skip 对齐到 60ms，Once 从 55ms 重新计时到 65ms
+		runs.Store(0)
+		timer.Advance(base.Add(62 * time.Millisecond))
+		want := int32(1)
+		if c.policy == CatchUpOnce {
+			want = 0
+		}
+		if runs.Load() != want {
+			t.Fatalf("policy %d: next runs=%d, want %d", c.policy, runs.Load(), want)
+		}
+	}
+}
+
+func TestTimerAddAdaptive(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	var runs atomic.Int32
+	done := make(chan struct{})
+	c := timer.AddAdaptive(func() (time.Duration, bool) {
+		n := runs.Add(1)
+		if n == 3 {
+			close(done)
+			return 0, false
+		}
+		return time.Duration(n) * 5 * time.Millisecond, true
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("adaptive task ran %d times", runs.Load())
+	}
+	if !c.IsStopped() {
+		t.Fatal("adaptive task not stopped after ok=false")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if runs.Load() != 3 {
+		t.Fatalf("runs=%d, want 3", runs.Load())
+	}
+}
+
+func TestTimerCronFunc(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	var calls, runs atomic.Int32
+	done := make(chan struct{})
+	timer.CronFunc(func(now time.Time) time.Time {
+		if calls.Add(1) > 3 {
+			close(done)
+			return time.Time{}
+		}
+		return now.Add(5 * time.Millisecond)
+	}, func() { runs.Add(1) })
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("CronFunc did not finish")
+	}
+	if runs.Load() != 3 {
+		t.Fatalf("runs=%d, want 3", runs.Load())
+	}
+}
+
+func TestCronIntervalAdaptive(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	var slow atomic.Bool
+	slow.Store(true)
+	var runs atomic.Int32
+	c := timer.CronIntervalAdaptive(5*time.Millisecond, 100*time.Millisecond, func() {
+		runs.Add(1)
+		if slow.Load() {
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+	defer c.Stop()
+
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if p := c.Period(); p < 20*time.Millisecond {
+		t.Fatalf("period=%v, want stretched to >= 20ms", p)
+	}
+
+	slow.Store(false)
+	for c.Period() != 5*time.Millisecond && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if p := c.Period(); p != 5*time.Millisecond {
+		t.Fatalf("period=%v, want shrunk back to 5ms", p)
+	}
+}
+
+func TestCronAdaptiveManualClock(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	// 自适应任务以定时器时间测量回调耗时
+	adaptive := timer.CronIntervalAdaptive(10*time.Millisecond, time.Second, func() {
+		timer.manualNow.Add(int64(30 * time.Millisecond))
+	})
+	timer.Advance(base.Add(10 * time.Millisecond))
+	if p := adaptive.Period(); p != 60*time.Millisecond {
+		t.Fatalf("adaptive period = %v, want 60ms", p)
+	}
+}
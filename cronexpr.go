@@ -0,0 +1,40 @@
+//go:build !whtimer_tiny && !tinygo
+
+package whTimer
+
+import (
+	"github.com/robfig/cron/v3"
+)
+
+// Cron 表达式依赖 robfig/cron，精简构建下不可用，可改用 AddSchedule、DailyAt 等不依赖解析器的接口
+
+// cron 表达式解析器 (支持秒级)
+var cronParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// ParseCron 解析 Cron 表达式为 Schedule，格式同 Cron
+func ParseCron(expr string) (Schedule, error) {
+	return cronParser.Parse(expr)
+}
+
+// Cron 使用 Cron 表达式创建周期任务
+// 格式: "秒 分 时 日 月 星期"
+// 示例: "0 30 9 * * 1-5" 每周一到周五 9:30:00 执行
+func (t *Timer) Cron(expr string, callback func()) (*CronEntry, error) {
+	schedule, err := cronParser.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.addSchedule(expr, schedule, callback), nil
+}
+
+// CronWithBackoff 创建可失败的 Cron 周期任务
+// 回调返回 error 或 panic 视为失败，连续失败达到阈值后下次发生时间按指数退避推迟，
+// 成功一次即恢复正常节奏
+func (t *Timer) CronWithBackoff(expr string, policy BackoffPolicy, fn func() error) (*CronEntry, error) {
+	schedule, err := ParseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+	return t.addScheduleWithBackoff(expr, schedule, policy, fn), nil
+}
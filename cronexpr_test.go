@@ -0,0 +1,30 @@
+//go:build !whtimer_tiny && !tinygo
+
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronManualClock(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	// Cron 与 After 以定时器时间为准，不受墙钟影响
+	var runs int
+	if _, err := timer.Cron("* * * * * *", func() { runs++ }); err != nil {
+		t.Fatal(err)
+	}
+	after := timer.After(500 * time.Millisecond)
+	for s := 1; s <= 3; s++ {
+		timer.Advance(base.Add(time.Duration(s) * time.Second))
+	}
+	if runs != 3 {
+		t.Fatalf("cron ran %d times in 3s of manual time, want 3", runs)
+	}
+	if at := <-after; !at.Equal(base.Add(time.Second)) {
+		t.Fatalf("After delivered %v, want the timer clock", at)
+	}
+}
@@ -0,0 +1,211 @@
+//go:build !whtimer_tiny && !tinygo
+
+package whTimer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Crontab 从 crontab 格式的文件加载周期任务
+// 每行为 "分 时 日 月 星期 命令 [参数...]" 或 "@daily 命令"、"@every 1m 命令"，
+// 命令列为通过 Register 注册的处理函数名；# 开头的注释、空行与环境变量行被忽略
+type Crontab struct {
+	timer *Timer
+
+	mu       sync.Mutex
+	handlers map[string]func(args []string)
+	entries  []*CronEntry
+	modTime  time.Time
+}
+
+// NewCrontab 创建 crontab 加载器
+func (t *Timer) NewCrontab() *Crontab {
+	return &Crontab{
+		timer:    t,
+		handlers: make(map[string]func(args []string)),
+	}
+}
+
+// Register 注册命令处理函数，args 为命令列之后的参数
+func (c *Crontab) Register(name string, fn func(args []string)) {
+	c.mu.Lock()
+	c.handlers[name] = fn
+	c.mu.Unlock()
+}
+
+type crontabLine struct {
+	spec     string
+	schedule Schedule
+	handler  func(args []string)
+	args     []string
+}
+
+// Load 解析并替换全部任务，任一行出错时返回错误并保留原有任务
+func (c *Crontab) Load(r io.Reader) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var lines []crontabLine
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line, err := c.parseLine(scanner.Text())
+		if err != nil {
+			return fmt.Errorf("whTimer: crontab line %d: %w", n, err)
+		}
+		if line != nil {
+			lines = append(lines, *line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	for _, e := range c.entries {
+		e.Stop()
+	}
+	c.entries = c.entries[:0]
+	for _, line := range lines {
+		handler, args := line.handler, line.args
+		c.entries = append(c.entries, c.timer.addSchedule(line.spec, line.schedule, func() { handler(args) }))
+	}
+	return nil
+}
+
+// parseLine 解析一行，注释、空行与环境变量行返回 nil
+func (c *Crontab) parseLine(text string) (*crontabLine, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.HasPrefix(text, "#") {
+		return nil, nil
+	}
+	fields := strings.Fields(text)
+	if strings.Contains(fields[0], "=") {
+		return nil, nil
+	}
+
+	// 描述符只占一列，@every 额外带一个时长
+	specLen := 5
+	if strings.HasPrefix(fields[0], "@") {
+		specLen = 1
+		if fields[0] == "@every" {
+			specLen = 2
+		}
+	}
+	if len(fields) <= specLen {
+		return nil, fmt.Errorf("missing command in %q", text)
+	}
+
+	spec := strings.Join(fields[:specLen], " ")
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, err
+	}
+	if specLen == 5 {
+		spec = "0 " + spec // 导出时统一为秒级格式
+	}
+	name := fields[specLen]
+	handler, ok := c.handlers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown command %q", name)
+	}
+	return &crontabLine{spec: spec, schedule: schedule, handler: handler, args: fields[specLen+1:]}, nil
+}
+
+// LoadFile 从文件加载，规则同 Load
+func (c *Crontab) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := c.Load(f); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.modTime = info.ModTime()
+	c.mu.Unlock()
+	return nil
+}
+
+// WatchFile 每隔 interval 检查文件修改时间，变化时重新加载
+// 重新加载失败时保留原有任务并调用 onError（可为 nil）
+func (c *Crontab) WatchFile(path string, interval time.Duration, onError func(error)) *CronEntry {
+	return c.timer.CronInterval(interval, func() {
+		info, err := os.Stat(path)
+		if err == nil {
+			c.mu.Lock()
+			changed := !info.ModTime().Equal(c.modTime)
+			c.mu.Unlock()
+			if !changed {
+				return
+			}
+			err = c.LoadFile(path)
+		}
+		if err != nil && onError != nil {
+			onError(err)
+		}
+	})
+}
+
+// ReloadOnSignal 收到信号时重新加载文件，未指定信号时使用 SIGHUP，返回停止函数
+func (c *Crontab) ReloadOnSignal(path string, onError func(error), sigs ...os.Signal) (stop func()) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{syscall.SIGHUP}
+	}
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, sigs...)
+
+	go func() {
+		for {
+			select {
+			case <-ch:
+				if err := c.LoadFile(path); err != nil && onError != nil {
+					onError(err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+		})
+	}
+}
+
+// Stop 停止全部已加载的任务
+func (c *Crontab) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range c.entries {
+		e.Stop()
+	}
+	c.entries = nil
+}
+
+// Len 返回已加载的任务数
+func (c *Crontab) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
@@ -0,0 +1,81 @@
+//go:build !whtimer_tiny && !tinygo
+
+package whTimer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCrontab(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	var runs atomic.Int32
+	got := make(chan []string, 1)
+	tab := timer.NewCrontab()
+	tab.Register("backup", func(args []string) { got <- args })
+	tab.Register("noop", func([]string) { runs.Add(1) })
+
+	err := tab.Load(strings.NewReader(`
+# 注释
+SHELL=/bin/sh
+@every 10ms backup --full /data
+0 3 * * * noop
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tab.Len() != 2 {
+		t.Fatalf("len=%d, want 2", tab.Len())
+	}
+	select {
+	case args := <-got:
+		if strings.Join(args, " ") != "--full /data" {
+			t.Fatalf("args=%v", args)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("crontab entry did not fire")
+	}
+
+	for _, bad := range []string{"0 3 * * * missing", "0 3 * * *", "61 * * * * noop"} {
+		if err := tab.Load(strings.NewReader(bad)); err == nil {
+			t.Errorf("Load(%q) accepted", bad)
+		}
+	}
+	if tab.Len() != 2 {
+		t.Fatalf("failed reload dropped entries: len=%d", tab.Len())
+	}
+
+	path := filepath.Join(t.TempDir(), "crontab")
+	if err := os.WriteFile(path, []byte("0 3 * * * noop\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := tab.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if tab.Len() != 1 {
+		t.Fatalf("len=%d, want 1", tab.Len())
+	}
+
+	w := tab.WatchFile(path, 5*time.Millisecond, func(err error) { t.Error(err) })
+	defer w.Stop()
+	later := time.Now().Add(time.Second)
+	if err := os.WriteFile(path, []byte("0 3 * * * noop\n0 4 * * * noop\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(path, later, later)
+	deadline := time.Now().Add(time.Second)
+	for tab.Len() != 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if tab.Len() != 2 {
+		t.Fatalf("watch did not reload: len=%d", tab.Len())
+	}
+	tab.Stop()
+}
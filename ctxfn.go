@@ -0,0 +1,99 @@
+package whTimer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRetainCancel AddEntryCtxFn 交给回调的 context 是否保留调度时 context 的取消与截止时间，默认不保留
+// 定时任务通常晚于发起它的请求执行，默认只保留 Value（追踪编号等请求元数据）；
+// 保留时请求结束后回调拿到的是已取消的 context
+func WithRetainCancel(retain bool) Option {
+	return func(t *Timer) {
+		t.retainCancel = retain
+	}
+}
+
+// WithStopGrace Stop 取消进行中的 AddEntryCtxFn 回调的 context 后，最多等待 grace 让其返回
+// 默认不等待；超过宽限期仍未返回的回调继续在后台运行
+func WithStopGrace(grace time.Duration) Option {
+	return func(t *Timer) {
+		t.stopGrace = grace
+	}
+}
+
+// AddEntryCtxFn 添加回调接收调度时 context 的定时任务 - Wait-Free
+// 调度时 context 中的值（追踪编号等）随任务保存并交给回调，也由 AddChild 派生的任务继承；
+// 是否保留其取消信号由 WithRetainCancel 决定，定时器 Stop 时回调的 context 总会被取消
+func (t *Timer) AddEntryCtxFn(ctx context.Context, delay time.Duration, fn func(ctx context.Context)) *Entry {
+	if !t.retainCancel {
+		ctx = context.WithoutCancel(ctx)
+	}
+	entry := t.newEntry(t.now().Add(delay), func() { t.runCtxFn(ctx, fn) })
+	entry.delay = delay
+	entry.ctx = ctx
+	return t.push(entry)
+}
+
+// runCtxFn 执行回调并登记为进行中，Stop 时取消其 context
+func (t *Timer) runCtxFn(ctx context.Context, fn func(ctx context.Context)) {
+	t.inflight.enter()
+	defer t.inflight.leave()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	unregister := context.AfterFunc(t.stopCtx, cancel)
+	defer unregister()
+	fn(ctx)
+}
+
+// inflight 统计进行中的 ctx 回调，供 Stop 等待其返回
+type inflight struct {
+	mu   sync.Mutex
+	n    int
+	idle chan struct{} // 有等待者时创建，计数归零时关闭
+}
+
+func (f *inflight) enter() {
+	f.mu.Lock()
+	f.n++
+	f.mu.Unlock()
+}
+
+func (f *inflight) leave() {
+	f.mu.Lock()
+	f.n--
+	if f.n == 0 && f.idle != nil {
+		close(f.idle)
+		f.idle = nil
+	}
+	f.mu.Unlock()
+}
+
+// wait 等待所有回调返回，最多等待 grace，返回是否全部返回
+func (f *inflight) wait(grace time.Duration) bool {
+	f.mu.Lock()
+	if f.n == 0 {
+		f.mu.Unlock()
+		return true
+	}
+	if grace <= 0 {
+		f.mu.Unlock()
+		return false
+	}
+	if f.idle == nil {
+		f.idle = make(chan struct{})
+	}
+	idle := f.idle
+	f.mu.Unlock()
+
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case <-idle:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
@@ -0,0 +1,68 @@
+package whTimer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimerAddEntryCtxFn(t *testing.T) {
+	type traceKey struct{}
+	base := time.Unix(1000, 0)
+	for _, retain := range []bool{false, true} {
+		timer := NewTimer(func(e *Entry) { e.Execute() }, WithRetainCancel(retain))
+		timer.Advance(base)
+
+		reqCtx, cancel := context.WithCancel(context.WithValue(context.Background(), traceKey{}, "trace-7"))
+		var got context.Context
+		var gotErr error
+		timer.AddEntryCtxFn(reqCtx, 10*time.Millisecond, func(ctx context.Context) { got, gotErr = ctx, ctx.Err() })
+		cancel() // 请求在任务触发前结束
+		timer.Advance(base.Add(10 * time.Millisecond))
+
+		if got == nil || got.Value(traceKey{}) != "trace-7" {
+			t.Fatalf("retain=%v: callback ctx lost values", retain)
+		}
+		if canceled := gotErr != nil; canceled != retain {
+			t.Fatalf("retain=%v: ctx err=%v", retain, gotErr)
+		}
+	}
+}
+
+func TestTimerStopCancelsCtxFn(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { go e.Execute() }, WithStopGrace(time.Second))
+	timer.Start()
+
+	started := make(chan struct{})
+	var cause error
+	timer.AddEntryCtxFn(context.Background(), 0, func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		time.Sleep(20 * time.Millisecond) // 模拟收尾工作
+		cause = ctx.Err()
+	})
+	<-started
+	timer.Stop()
+	// Stop 在回调收尾后才返回
+	if !errors.Is(cause, context.Canceled) {
+		t.Fatalf("callback not canceled before Stop returned: %v", cause)
+	}
+
+	// 忽略取消的回调最多等待宽限期
+	timer = NewTimer(func(e *Entry) { go e.Execute() }, WithStopGrace(50*time.Millisecond))
+	timer.Start()
+	started = make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	timer.AddEntryCtxFn(context.Background(), 0, func(ctx context.Context) {
+		close(started)
+		<-release
+	})
+	<-started
+	begin := time.Now()
+	timer.Stop()
+	if elapsed := time.Since(begin); elapsed < 40*time.Millisecond || elapsed > time.Second {
+		t.Fatalf("Stop waited %v, want about the grace period", elapsed)
+	}
+}
@@ -0,0 +1,82 @@
+package whTimer
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// DeadlineError 由时间轮到期取消 context 的原因，记录计划与实际的到期时间，便于排查超时
+// errors.Is(err, context.DeadlineExceeded) 为 true
+type DeadlineError struct {
+	Scheduled time.Time // 计划的截止时间
+	Fired     time.Time // 时间轮实际取消 context 的时间
+}
+
+func (e *DeadlineError) Error() string {
+	return fmt.Sprintf("whTimer: deadline %s exceeded (fired %s late)",
+		e.Scheduled.Format(time.RFC3339Nano), e.Fired.Sub(e.Scheduled))
+}
+
+// Is 与 context.DeadlineExceeded 匹配
+func (e *DeadlineError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// ContextWithFireTime 创建在 at 由时间轮取消的 context，代替 context.WithDeadline 的运行时定时器
+// 到期后 Err 返回 context.DeadlineExceeded，context.Cause 返回 *DeadlineError
+// 调用方应在不再需要时调用 cancel 以取消时间轮条目
+func ContextWithFireTime(parent context.Context, t *Timer, at time.Time) (context.Context, context.CancelFunc) {
+	c, cancel, ref := t.withWheelDeadline(parent, at, t.deadlineCause(at))
+	return c, func() {
+		ref.Cancel()
+		cancel(context.Canceled)
+	}
+}
+
+// deadlineCause 返回在到期时记录实际时间的 DeadlineError 生成函数
+func (t *Timer) deadlineCause(at time.Time) func() error {
+	return func() error {
+		return &DeadlineError{Scheduled: at, Fired: t.now()}
+	}
+}
+
+// wheelContext 由时间轮条目负责到期的 context
+// 内部使用 WithCancelCause，context.Cause 可取得到期原因，Err 在到期后返回 context.DeadlineExceeded
+type wheelContext struct {
+	context.Context
+	deadline time.Time
+	cause    atomic.Pointer[error] // 到期时设置的原因
+}
+
+// withWheelDeadline 创建在 at 由时间轮取消的 context，到期原因由 cause 在到期时生成
+// 返回的 cancel 可提前以指定原因结束 context，不再需要时应取消返回的条目引用
+func (t *Timer) withWheelDeadline(parent context.Context, at time.Time, cause func() error) (*wheelContext, context.CancelCauseFunc, EntryRef) {
+	inner, cancel := context.WithCancelCause(parent)
+	c := &wheelContext{Context: inner, deadline: at}
+	_, ref := t.addEntryRef(at, func() {
+		err := cause()
+		c.cause.Store(&err)
+		cancel(err)
+	})
+	return c, cancel, ref
+}
+
+// Deadline 返回自身与父 context 中较早的截止时间
+func (c *wheelContext) Deadline() (time.Time, bool) {
+	if d, ok := c.Context.Deadline(); ok && d.Before(c.deadline) {
+		return d, true
+	}
+	return c.deadline, true
+}
+
+// Err 由时间轮到期取消时返回 context.DeadlineExceeded
+// 由其派生的子 context 只能看到 context.Canceled，到期原因需通过 context.Cause 获取
+func (c *wheelContext) Err() error {
+	err := c.Context.Err()
+	if cause := c.cause.Load(); err != nil && cause != nil && context.Cause(c.Context) == *cause {
+		return context.DeadlineExceeded
+	}
+	return err
+}
@@ -0,0 +1,43 @@
+package whTimer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestContextWithFireTime(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	at := base.Add(50 * time.Millisecond)
+	ctx, cancel := ContextWithFireTime(context.Background(), timer, at)
+	defer cancel()
+	if d, ok := ctx.Deadline(); !ok || !d.Equal(at) {
+		t.Fatalf("Deadline=%v", d)
+	}
+	timer.Advance(base.Add(49 * time.Millisecond))
+	if ctx.Err() != nil {
+		t.Fatal("context canceled before deadline")
+	}
+	timer.Advance(base.Add(53 * time.Millisecond))
+	<-ctx.Done()
+
+	var de *DeadlineError
+	cause := context.Cause(ctx)
+	if !errors.As(cause, &de) || !de.Scheduled.Equal(at) || de.Fired.Sub(at) != 3*time.Millisecond {
+		t.Fatalf("cause=%v", cause)
+	}
+	if ctx.Err() != context.DeadlineExceeded || !errors.Is(cause, context.DeadlineExceeded) {
+		t.Fatalf("err=%v", ctx.Err())
+	}
+
+	early, cancelEarly := ContextWithFireTime(context.Background(), timer, base.Add(time.Second))
+	cancelEarly()
+	timer.Advance(base.Add(2 * time.Second))
+	if early.Err() != context.Canceled || context.Cause(early) != context.Canceled {
+		t.Fatalf("canceled err=%v cause=%v", early.Err(), context.Cause(early))
+	}
+}
@@ -0,0 +1,71 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client whtimerd HTTP API 客户端
+type Client struct {
+	BaseURL string       // 如 "http://127.0.0.1:7070"
+	HTTP    *http.Client // 为 nil 时使用 http.DefaultClient
+}
+
+func (c *Client) do(method, path string, body, out any) error {
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.BaseURL, "/")+path, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode >= 300:
+		var e struct{ Error string }
+		json.NewDecoder(resp.Body).Decode(&e)
+		return fmt.Errorf("daemon: %s: %s", resp.Status, e.Error)
+	case out != nil:
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
+
+// Create 创建任务
+func (c *Client) Create(req CreateRequest) (Job, error) {
+	var job Job
+	err := c.do(http.MethodPost, "/jobs", req, &job)
+	return job, err
+}
+
+// List 按下次触发时间列出任务
+func (c *Client) List() ([]Job, error) {
+	var jobs []Job
+	err := c.do(http.MethodGet, "/jobs", nil, &jobs)
+	return jobs, err
+}
+
+// Cancel 取消任务
+func (c *Client) Cancel(id string) error {
+	return c.do(http.MethodDelete, "/jobs/"+url.PathEscape(id), nil, nil)
+}
@@ -0,0 +1,346 @@
+// Package daemon 独立延迟任务服务的核心实现，供 cmd/whtimerd 的各种传输层复用
+// 任务到期时以 HTTP 回调通知调用方，任务定义持久化到 JSON 文件，重启后自动恢复
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"whTimer"
+	"whTimer/webhook"
+)
+
+// ErrNotFound 任务不存在
+var ErrNotFound = errors.New("daemon: job not found")
+
+// ErrPersist 状态文件写入失败，创建任务时返回该错误则任务未被添加
+var ErrPersist = errors.New("daemon: save state")
+
+// Target 任务到期时的 HTTP 回调
+type Target struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// Job 任务定义，At 与 Cron 二选一
+type Job struct {
+	ID     string    `json:"id"`
+	At     time.Time `json:"at,omitzero"`
+	Cron   string    `json:"cron,omitempty"`
+	Target Target    `json:"target"`
+	Next   time.Time `json:"next,omitzero"` // 下次触发时间，仅查询时填充
+}
+
+type job struct {
+	Job
+	entry *whTimer.Entry
+	cron  *whTimer.CronEntry
+}
+
+// state 状态文件格式，记录已分配的最大编号以免重启后复用已取消任务的编号
+type state struct {
+	NextID uint64 `json:"next_id"`
+	Jobs   []Job  `json:"jobs"`
+}
+
+// Service 延迟任务服务
+type Service struct {
+	timer *whTimer.Timer
+	hooks *webhook.Scheduler
+	path  string
+
+	mu     sync.Mutex
+	jobs   map[string]*job
+	nextID uint64
+	dirty  bool // 内存中的任务与状态文件不一致，等待持久化 goroutine 写回
+
+	// 一次性任务触发后的删除由持久化 goroutine 完成，run loop 中只记录编号，不持锁写盘
+	firedMu sync.Mutex
+	fired   []string
+	saveReq chan struct{}
+	closing chan struct{}
+	closed  chan struct{}
+}
+
+// NewService 创建服务，path 非空时从该文件恢复任务并在每次变更后写回
+// 已过期的一次性任务在恢复后立即触发；timer 需由调用方启动，停止 timer 后调用 Close
+func NewService(timer *whTimer.Timer, path string, opts ...webhook.Option) (*Service, error) {
+	s := &Service{
+		timer:   timer,
+		hooks:   webhook.New(timer, opts...),
+		path:    path,
+		jobs:    make(map[string]*job),
+		saveReq: make(chan struct{}, 1),
+		closing: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+	if err := s.restore(); err != nil {
+		return nil, err
+	}
+	go s.persist()
+	return s, nil
+}
+
+// restore 从状态文件恢复任务
+func (s *Service) restore() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		return fmt.Errorf("daemon: invalid state file %s: %w", s.path, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID = st.NextID
+	for _, j := range st.Jobs {
+		if err := s.start(j); err != nil {
+			return fmt.Errorf("daemon: restore job %s: %w", j.ID, err)
+		}
+	}
+	return nil
+}
+
+// Close 写回尚未持久化的变更并停止持久化 goroutine，返回最后一次写入的错误
+func (s *Service) Close() error {
+	select {
+	case <-s.closing:
+	default:
+		close(s.closing)
+	}
+	<-s.closed
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reapLocked()
+	if s.dirty {
+		return s.saveLocked()
+	}
+	return nil
+}
+
+// persist 合并一次性任务触发后的删除并写回状态文件，写入失败时记录日志，下次变更时重试
+func (s *Service) persist() {
+	defer close(s.closed)
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-s.saveReq:
+		}
+		s.mu.Lock()
+		s.reapLocked()
+		if s.dirty {
+			if err := s.saveLocked(); err != nil {
+				log.Printf("daemon: save state: %v", err)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// markFired 记录已触发的一次性任务并通知持久化 goroutine，在 run loop 中调用，不阻塞
+func (s *Service) markFired(id string) {
+	s.firedMu.Lock()
+	s.fired = append(s.fired, id)
+	s.firedMu.Unlock()
+	select {
+	case s.saveReq <- struct{}{}:
+	default:
+	}
+}
+
+// reapLocked 删除已触发的一次性任务并标记待写回，调用方需持有锁
+func (s *Service) reapLocked() {
+	s.firedMu.Lock()
+	fired := s.fired
+	s.fired = nil
+	s.firedMu.Unlock()
+	for _, id := range fired {
+		delete(s.jobs, id)
+		s.dirty = true
+	}
+}
+
+// ScheduleAt 在 at 时刻回调一次
+func (s *Service) ScheduleAt(at time.Time, target Target) (Job, error) {
+	return s.add(Job{At: at, Target: target})
+}
+
+// ScheduleCron 按秒级 Cron 表达式周期回调
+func (s *Service) ScheduleCron(expr string, target Target) (Job, error) {
+	return s.add(Job{Cron: expr, Target: target})
+}
+
+func (s *Service) add(j Job) (Job, error) {
+	if j.Target.URL == "" {
+		return Job{}, errors.New("daemon: missing target url")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reapLocked()
+	s.nextID++
+	j.ID = strconv.FormatUint(s.nextID, 10)
+	if err := s.start(j); err != nil {
+		s.nextID--
+		return Job{}, err
+	}
+	if err := s.saveLocked(); err != nil {
+		// 未能持久化的任务重启后会丢失，撤销调度使内存与状态文件保持一致
+		s.stopLocked(s.jobs[j.ID])
+		delete(s.jobs, j.ID)
+		s.nextID--
+		return Job{}, fmt.Errorf("%w: %w", ErrPersist, err)
+	}
+	return s.describe(s.jobs[j.ID]), nil
+}
+
+// stopLocked 停止任务的调度，调用方需持有锁
+func (s *Service) stopLocked(j *job) {
+	if j.cron != nil {
+		j.cron.Stop()
+	} else {
+		j.entry.Cancel()
+	}
+}
+
+// start 调度任务，调用方需持有锁
+func (s *Service) start(j Job) error {
+	spec := webhook.Spec{
+		Method: j.Target.Method,
+		URL:    j.Target.URL,
+		Body:   []byte(j.Target.Body),
+		Header: make(http.Header, len(j.Target.Headers)),
+	}
+	for k, v := range j.Target.Headers {
+		spec.Header.Set(k, v)
+	}
+	if err := webhook.Validate(spec); err != nil {
+		return err
+	}
+
+	jb := &job{Job: j}
+	switch {
+	case j.Cron != "":
+		c, err := s.timer.Cron(j.Cron, func() {
+			s.deliver(j.ID, spec)
+		})
+		if err != nil {
+			return err
+		}
+		jb.cron = c
+	case !j.At.IsZero():
+		jb.entry = s.timer.AddEntryAt(j.At, func() {
+			s.deliver(j.ID, spec)
+			s.markFired(j.ID)
+		})
+	default:
+		return errors.New("daemon: job needs at or cron")
+	}
+	s.jobs[j.ID] = jb
+	return nil
+}
+
+// deliver 到期时发出回调，在 run loop 中调用
+func (s *Service) deliver(id string, spec webhook.Spec) {
+	if _, err := s.hooks.ScheduleWebhook(time.Now(), spec); err != nil {
+		log.Printf("daemon: job %s: %v", id, err)
+	}
+}
+
+// Cancel 取消任务
+func (s *Service) Cancel(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reapLocked()
+	j, ok := s.jobs[id]
+	if !ok {
+		return ErrNotFound
+	}
+	s.stopLocked(j)
+	delete(s.jobs, id)
+	return s.saveLocked()
+}
+
+// List 按下次触发时间返回全部任务
+func (s *Service) List() []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reapLocked()
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, s.describe(j))
+	}
+	slices.SortFunc(jobs, func(a, b Job) int { return a.Next.Compare(b.Next) })
+	return jobs
+}
+
+func (s *Service) describe(j *job) Job {
+	d := j.Job
+	if j.cron != nil {
+		if schedule, err := whTimer.ParseCron(j.Cron); err == nil {
+			d.Next = schedule.Next(time.Now())
+		}
+	} else {
+		d.Next = j.At
+	}
+	return d
+}
+
+// saveLocked 写回状态文件，先写临时文件再重命名保证原子性
+func (s *Service) saveLocked() error {
+	if s.path == "" {
+		s.dirty = false
+		return nil
+	}
+	st := state{NextID: s.nextID, Jobs: make([]Job, 0, len(s.jobs))}
+	for _, j := range s.jobs {
+		st.Jobs = append(st.Jobs, j.Job)
+	}
+	slices.SortFunc(st.Jobs, func(a, b Job) int { return cmpID(a.ID, b.ID) })
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return err
+	}
+	s.dirty = false
+	return nil
+}
+
+func cmpID(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,218 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"whTimer"
+)
+
+func TestService(t *testing.T) {
+	hits := make(chan string, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits <- r.URL.Path
+	}))
+	defer srv.Close()
+
+	timer := whTimer.NewTimer(func(e *whTimer.Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	svc, err := NewService(timer, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svc.Close()
+
+	if _, err := svc.ScheduleAt(time.Now().Add(10*time.Millisecond), Target{URL: srv.URL + "/once"}); err != nil {
+		t.Fatal(err)
+	}
+	cron, err := svc.ScheduleCron("0 0 0 1 1 *", Target{URL: srv.URL + "/yearly"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	far, _ := svc.ScheduleAt(time.Now().Add(time.Hour), Target{URL: srv.URL + "/later"})
+	if _, err := svc.ScheduleCron("bad", Target{URL: srv.URL}); err == nil {
+		t.Fatal("invalid cron accepted")
+	}
+	if _, err := svc.ScheduleAt(time.Now(), Target{URL: "ftp://x"}); err == nil {
+		t.Fatal("invalid url accepted")
+	}
+
+	select {
+	case p := <-hits:
+		if p != "/once" {
+			t.Fatalf("hit %s", p)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("one-shot job not delivered")
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(svc.List()) != 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	jobs := svc.List()
+	if len(jobs) != 2 || jobs[0].ID != far.ID || jobs[1].ID != cron.ID {
+		t.Fatalf("jobs=%+v", jobs)
+	}
+
+	if err := svc.Cancel(far.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Cancel(far.ID); err != ErrNotFound {
+		t.Fatalf("second cancel: %v", err)
+	}
+
+	// 重启后从状态文件恢复
+	restored, err := NewService(timer, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobs := restored.List(); len(jobs) != 1 || jobs[0].Cron != "0 0 0 1 1 *" {
+		t.Fatalf("restored=%+v", jobs)
+	}
+	next, _ := restored.ScheduleAt(time.Now().Add(time.Hour), Target{URL: srv.URL})
+	if next.ID == cron.ID || next.ID == far.ID {
+		t.Fatalf("restored service reused id %s", next.ID)
+	}
+}
+
+func TestHTTPAPI(t *testing.T) {
+	timer := whTimer.NewTimer(func(e *whTimer.Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	svc, _ := NewService(timer, "")
+	api := httptest.NewServer(svc.Handler())
+	defer api.Close()
+
+	post := func(body string) *http.Response {
+		resp, err := http.Post(api.URL+"/jobs", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	resp := post(`{"delay": "1h", "target": {"url": "http://127.0.0.1:1/hook"}}`)
+	var job Job
+	json.NewDecoder(resp.Body).Decode(&job)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated || job.ID == "" || job.Next.IsZero() {
+		t.Fatalf("create: status=%d job=%+v", resp.StatusCode, job)
+	}
+	for _, bad := range []string{`{`, `{"target": {"url": "http://x"}}`, `{"cron": "bad", "target": {"url": "http://x"}}`} {
+		if resp := post(bad); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("POST %s: status=%d", bad, resp.StatusCode)
+		}
+	}
+
+	resp, _ = http.Get(api.URL + "/jobs")
+	var jobs []Job
+	json.NewDecoder(resp.Body).Decode(&jobs)
+	resp.Body.Close()
+	if len(jobs) != 1 || jobs[0].ID != job.ID {
+		t.Fatalf("list=%+v", jobs)
+	}
+
+	del := func() int {
+		req, _ := http.NewRequest(http.MethodDelete, api.URL+"/jobs/"+job.ID, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if code := del(); code != http.StatusNoContent {
+		t.Fatalf("delete: %d", code)
+	}
+	if code := del(); code != http.StatusNotFound {
+		t.Fatalf("second delete: %d", code)
+	}
+}
+
+func TestServiceSaveFailure(t *testing.T) {
+	timer := whTimer.NewTimer(func(e *whTimer.Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	// 状态文件所在目录不存在：写入失败时任务不应留在调度中
+	svc, err := NewService(timer, filepath.Join(t.TempDir(), "missing", "jobs.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ScheduleCron("0 0 0 1 1 *", Target{URL: "http://127.0.0.1:1/"}); !errors.Is(err, ErrPersist) {
+		t.Fatalf("ScheduleCron err=%v, want ErrPersist", err)
+	}
+	if jobs := svc.List(); len(jobs) != 0 || len(timer.Crons()) != 0 {
+		t.Fatalf("unsaved job still scheduled: %v", jobs)
+	}
+
+	api := httptest.NewServer(svc.Handler())
+	defer api.Close()
+	resp, err := http.Post(api.URL+"/jobs", "application/json",
+		strings.NewReader(`{"delay": "1h", "target": {"url": "http://127.0.0.1:1/"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("POST /jobs status=%d, want 500", resp.StatusCode)
+	}
+}
+
+func TestServiceFiredPersisted(t *testing.T) {
+	hits := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits <- struct{}{}
+	}))
+	defer srv.Close()
+
+	timer := whTimer.NewTimer(func(e *whTimer.Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	svc, err := NewService(timer, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svc.Close()
+
+	if _, err := svc.ScheduleAt(time.Now().Add(5*time.Millisecond), Target{URL: srv.URL}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-hits:
+	case <-time.After(time.Second):
+		t.Fatal("one-shot job not delivered")
+	}
+
+	// 触发后的删除由持久化 goroutine 写回，不在 run loop 中写盘
+	deadline := time.Now().Add(time.Second)
+	for {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var st state
+		if err := json.Unmarshal(data, &st); err != nil {
+			t.Fatal(err)
+		}
+		if len(st.Jobs) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("fired job still in state file: %+v", st.Jobs)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
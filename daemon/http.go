@@ -0,0 +1,84 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// CreateRequest POST /jobs 请求体，delay、at、cron 三选一
+type CreateRequest struct {
+	Delay  string    `json:"delay,omitempty"` // time.ParseDuration 格式
+	At     time.Time `json:"at,omitzero"`
+	Cron   string    `json:"cron,omitempty"`
+	Target Target    `json:"target"`
+}
+
+// Handler 返回 HTTP JSON API:
+//
+//	POST   /jobs       创建任务，返回 201 与任务
+//	GET    /jobs       按下次触发时间列出任务
+//	DELETE /jobs/{id}  取消任务，不存在时返回 404
+func (s *Service) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /jobs", s.handleCreate)
+	mux.HandleFunc("GET /jobs", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.List())
+	})
+	mux.HandleFunc("DELETE /jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch err := s.Cancel(r.PathValue("id")); {
+		case errors.Is(err, ErrNotFound):
+			writeError(w, http.StatusNotFound, err)
+		case err != nil:
+			writeError(w, http.StatusInternalServerError, err)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	return mux
+}
+
+func (s *Service) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var (
+		job Job
+		err error
+	)
+	switch {
+	case req.Cron != "":
+		job, err = s.ScheduleCron(req.Cron, req.Target)
+	case req.Delay != "":
+		var d time.Duration
+		if d, err = time.ParseDuration(req.Delay); err == nil {
+			job, err = s.ScheduleAt(time.Now().Add(d), req.Target)
+		}
+	case !req.At.IsZero():
+		job, err = s.ScheduleAt(req.At, req.Target)
+	default:
+		err = errors.New("daemon: need delay, at or cron")
+	}
+	switch {
+	case errors.Is(err, ErrPersist):
+		writeError(w, http.StatusInternalServerError, err)
+	case err != nil:
+		writeError(w, http.StatusBadRequest, err)
+	default:
+		writeJSON(w, http.StatusCreated, job)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,6 @@
+//go:build whtimer_debug
+
+package whTimer
+
+// debugChecks 调试模式，使用 -tags whtimer_debug 开启
+const debugChecks = true
@@ -0,0 +1,36 @@
+//go:build whtimer_debug
+
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDebugDoublePush(t *testing.T) {
+	q := NewMPSCQueue()
+	entry := NewEntry(time.Now(), func() {})
+	q.Push(entry)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on double push")
+		}
+	}()
+	q.Push(entry)
+}
+
+func TestDebugPushReleased(t *testing.T) {
+	q := NewMPSCQueue()
+	entry := NewEntry(time.Now(), func() {})
+	entry.Release()
+	entry.detach()
+	entry.tryRecycle()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on push of released entry")
+		}
+	}()
+	q.Push(entry)
+}
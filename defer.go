@@ -18,7 +18,7 @@ func (t *Timer) AfterFuncAt(at time.Time, f func()) *Entry {
 func (t *Timer) After(d time.Duration) <-chan time.Time {
 	c := make(chan time.Time, 1)
 	t.AddEntry(d, func() {
-		c <- time.Now()
+		c <- t.now()
 	})
 	return c
 }
@@ -0,0 +1,82 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// DelayStats 新添加任务的延迟分布（Refresh、Snooze 等重新放入的不计入），按能容纳该延迟的最低时间轮层级分桶
+// 时间轮几何（每层 64 槽、1ms 基准刻度）是编译期常量，层级随任务延迟自动升降，无需手工调整；
+// 该分布用于判断工作负载集中在哪些层级，以及是否适合 WithSmallHeap、WithCompactFarFuture 等配置；
+// WithAutoTune 按同样的分布自动选择小堆阈值与时间轮最低层级
+type DelayStats struct {
+	Due    uint64               // 放入时已到期、直接触发的任务数
+	Levels [MaxLevel + 1]uint64 // Levels[l] 为延迟在 [64^l, 64^(l+1)) 毫秒内的任务数（第 0 层从 1 毫秒起）
+	Max    time.Duration        // 观察到的最大延迟
+}
+
+// Total 返回统计的任务总数
+func (s DelayStats) Total() uint64 {
+	n := s.Due
+	for _, c := range s.Levels {
+		n += c
+	}
+	return n
+}
+
+// Quantile 返回延迟分布 q 分位所在的层级，已到期的任务计为 -1；无统计数据时返回 -1
+// 例如 Quantile(0.99) == 1 表示 99% 的任务延迟小于 4096 毫秒
+func (s DelayStats) Quantile(q float64) int {
+	total := s.Total()
+	if total == 0 {
+		return -1
+	}
+	rank := uint64(q * float64(total))
+	seen := s.Due
+	if seen > rank {
+		return -1
+	}
+	for level, c := range s.Levels {
+		seen += c
+		if seen > rank {
+			return level
+		}
+	}
+	return MaxLevel
+}
+
+// WithDelayStats 统计新添加任务的延迟分布，通过 DelayStats 读取
+func WithDelayStats(enabled bool) Option {
+	return func(t *Timer) {
+		if enabled {
+			t.delayStats = &DelayStats{}
+		} else {
+			t.delayStats = nil
+		}
+	}
+}
+
+// DelayStats 返回延迟分布，未开启 WithDelayStats 时返回零值
+func (t *Timer) DelayStats() DelayStats {
+	var s DelayStats
+	t.inspect(func() {
+		if t.delayStats != nil {
+			s = *t.delayStats
+		}
+	})
+	return s
+}
+
+// record 记录一个任务的延迟，仅在 run loop 中调用
+func (s *DelayStats) record(delay time.Duration) {
+	if delay <= 0 {
+		s.Due++
+		return
+	}
+	s.Max = max(s.Max, delay)
+	interval := ceilMs(delay)
+	level := 0
+	for level < MaxLevel && interval >= maxMs[level] {
+		level++
+	}
+	s.Levels[level]++
+}
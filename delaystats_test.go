@@ -0,0 +1,40 @@
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerDelayStats(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithDelayStats(true))
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+	for i := 0; i < 98; i++ {
+		timer.AddEntry(time.Duration(1+i%60)*time.Millisecond, func() {})
+	}
+	timer.AddEntry(time.Minute, func() {})
+	timer.AddEntry(0, func() {})
+	timer.Advance(base)
+
+	s := timer.DelayStats()
+	if s.Total() != 100 || s.Due != 1 || s.Levels[0] != 98 || s.Levels[2] != 1 || s.Max != time.Minute {
+		t.Fatalf("stats = %+v", s)
+	}
+	if q := s.Quantile(0.5); q != 0 {
+		t.Fatalf("p50 level = %d, want 0", q)
+	}
+	if q := s.Quantile(0.995); q != 2 {
+		t.Fatalf("p99.5 level = %d, want 2", q)
+	}
+
+	// Snooze 到期后重新放入时间轮不计为新任务
+	e := timer.AddEntry(10*time.Millisecond, func() {})
+	e.Snooze(time.Minute)
+	timer.Advance(base.Add(20 * time.Millisecond))
+	if e.State() != StateScheduled {
+		t.Fatalf("snoozed entry state = %v", e.State())
+	}
+	if got := timer.DelayStats().Total(); got != 101 {
+		t.Fatalf("total after snooze = %d, want 101", got)
+	}
+}
@@ -0,0 +1,92 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// DriftEvent 时钟漂移事件
+type DriftEvent struct {
+	Uptime time.Duration // 开始监控以来单调时钟经过的时长
+	// Wheel 单调时钟经过的时长减去时间轮已处理到的毫秒数，正值表示时间轮落后（任务延迟触发）
+	// 正常不足 1ms；到期回调阻塞 run loop、start 的调整存在舍入或记账错误时增大
+	Wheel time.Duration
+	// Wall 墙钟相对单调时钟的累计偏差，来自 NTP 调整、手动改时间或挂起期间单调时钟停走；
+	// 影响以绝对时间添加的任务（AddEntryAt、Cron）
+	Wall time.Duration
+}
+
+// driftMonitor 比较时间轮记账与单调时钟、墙钟与单调时钟
+type driftMonitor struct {
+	threshold time.Duration
+	fn        func(DriftEvent)
+
+	origin     time.Time // 开始监控的时间，带单调时钟读数
+	epochStart time.Time // 时间轮本次创建时的 start
+	epochMs    uint64    // 本次创建以来按整槽推进的毫秒数
+	wheelMs    uint64    // 本次创建以来时间轮已处理到的毫秒数
+	reported   DriftEvent
+	last       DriftEvent // run loop 最近一次测得的漂移量
+}
+
+// WithDriftMonitor 持续比较时间轮处理进度与单调时钟、墙钟与单调时钟，
+// 任一累计偏差相对上次报告变化超过 threshold 时在 run loop 中调用 fn
+// 长时间运行时用于发现回调阻塞 run loop、start 调整的累积误差与系统时钟被改动
+func WithDriftMonitor(threshold time.Duration, fn func(DriftEvent)) Option {
+	return func(t *Timer) {
+		t.drift = &driftMonitor{threshold: max(threshold, time.Millisecond), fn: fn}
+	}
+}
+
+// ClockDrift 返回 run loop 最近一次处理到期任务时测得的漂移量，未设置 WithDriftMonitor 时返回零值
+// 空闲等待期间时间轮不推进，此时测量会把等待时长误计为落后，因此不在调用时重新测量
+func (t *Timer) ClockDrift() DriftEvent {
+	var ev DriftEvent
+	t.inspect(func() {
+		if t.drift != nil {
+			ev = t.drift.last
+		}
+	})
+	return ev
+}
+
+// resetEpoch 时间轮重新创建，start 直接对齐到当前时间
+func (d *driftMonitor) resetEpoch(start time.Time) {
+	d.epochStart = start
+	d.epochMs = 0
+	d.wheelMs = 0
+}
+
+// measure 计算当前漂移量，在时间轮处理完到期任务后调用
+func (d *driftMonitor) measure(t *Timer) DriftEvent {
+	now := t.now()
+	if d.origin.IsZero() {
+		d.origin = now
+	}
+	ev := DriftEvent{
+		Uptime: now.Sub(d.origin),
+		Wall:   now.Round(0).Sub(d.origin.Round(0)) - now.Sub(d.origin),
+	}
+	if t.wheel != nil {
+		ev.Wheel = now.Sub(d.epochStart) - time.Duration(d.wheelMs)*time.Millisecond
+	}
+	return ev
+}
+
+// checkDrift 漂移量相对上次报告变化超过阈值时报告
+func (t *Timer) checkDrift() {
+	d := t.drift
+	if d == nil {
+		return
+	}
+	ev := d.measure(t)
+	d.last = ev
+	if (ev.Wheel-d.reported.Wheel).Abs() < d.threshold && (ev.Wall-d.reported.Wall).Abs() < d.threshold {
+		return
+	}
+	d.reported = ev
+	if d.fn != nil {
+		t.enterCallback()
+		d.fn(ev)
+		t.exitCallback()
+	}
+}
@@ -0,0 +1,58 @@
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerDriftMonitor(t *testing.T) {
+	var events []DriftEvent
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithDriftMonitor(2*time.Millisecond, func(ev DriftEvent) {
+		events = append(events, ev)
+	}))
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+	timer.AddEntry(time.Hour, func() {})
+	for i := 1; i <= 100; i++ {
+		timer.Advance(base.Add(time.Duration(i) * 37 * time.Millisecond))
+	}
+	if len(events) != 0 || timer.ClockDrift().Wheel != 0 {
+		t.Fatalf("unexpected drift: %v %+v", events, timer.ClockDrift())
+	}
+
+	// 模拟未经记账的 start 调整
+	timer.start = timer.start.Add(3 * time.Millisecond)
+	timer.Advance(base.Add(4 * time.Second))
+	if len(events) != 1 || events[0].Wheel != 3*time.Millisecond || events[0].Uptime != 4*time.Second {
+		t.Fatalf("events = %+v, want one with 3ms wheel drift", events)
+	}
+	// 偏差不再变化时不重复报告
+	timer.Advance(base.Add(5 * time.Second))
+	if len(events) != 1 {
+		t.Fatalf("drift reported again: %+v", events)
+	}
+}
+
+func TestTimerDriftMonitorStall(t *testing.T) {
+	events := make(chan DriftEvent, 16)
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithDriftMonitor(10*time.Millisecond, func(ev DriftEvent) {
+		events <- ev
+	}))
+	timer.Start()
+	defer timer.Stop()
+
+	// 回调阻塞 run loop 期间单调时钟继续前进，时间轮处理到的位置落后
+	timer.AddEntry(time.Hour, func() {})
+	timer.AddEntry(5*time.Millisecond, func() { time.Sleep(30 * time.Millisecond) })
+	select {
+	case ev := <-events:
+		if ev.Wheel < 30*time.Millisecond {
+			t.Fatalf("wheel drift = %v, want >= 30ms", ev.Wheel)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stall not reported")
+	}
+	if d := timer.ClockDrift().Wheel; d < 30*time.Millisecond {
+		t.Fatalf("ClockDrift().Wheel = %v, want the stall", d)
+	}
+}
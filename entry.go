@@ -1,22 +1,72 @@
 package whTimer
 
 import (
-	"sync"
+	"context"
+	"runtime"
 	"sync/atomic"
 	"time"
 	"unsafe"
 )
 
-// 哨兵值，表示next正在被设置
-var settingNext = unsafe.Pointer(new(Entry))
+// EntryState 定时任务状态
+type EntryState uint32
 
-// entryPool 对象池
-var entryPool = sync.Pool{
-	New: func() any {
-		return &Entry{}
-	},
+const (
+	StateQueued    EntryState = iota // 已提交，等待 run loop 接收
+	StateScheduled                   // 已放入时间轮
+	StateFired                       // 回调已执行
+	StateCanceled                    // 触发前被取消
+	StateDropped                     // 定时器停止时被丢弃，未执行
+	StateRecycled                    // 条目已被回收复用，原任务状态不可知（仅 EntryRef）
+)
+
+// 状态字布局: 低 4 位为 EntryState，4-15 位为标志位，高位为代数
+// 条目每次从对象池取出复用时代数加一，旧代数的 EntryRef 随即失效
+const (
+	stateMask    = 1<<4 - 1
+	flagDetached = 1 << 4  // 定时器已不再引用该条目
+	flagReleased = 1 << 5  // 已请求释放
+	flagPooled   = 1 << 6  // 已放回对象池
+	flagExposed  = 1 << 7  // 调用方持有 *Entry，只在显式 Release 后回收
+	flagNoPool   = 1 << 8  // 直接分配，不放回对象池
+	flagPushed   = 1 << 9  // 已入队（仅调试模式记录）
+	flagWatched  = 1 << 10 // 已注册结束通知
+	flagSlot     = 1 << 11 // 占用 WithConcurrencyLimit 的空位
+	flagDefer    = 1 << 12 // Refresh、Snooze 正在记录新的截止时间，或 run loop 正在判断是否推迟
+	flagSealed   = 1 << 13 // run loop 已决定触发，不再接受推迟
+	flagSpilled  = 1 << 14 // 已溢出到外部存储，取消时记入所属定时器的取消索引
+	genShift     = 16
+)
+
+// String 返回状态名称
+func (s EntryState) String() string {
+	switch s {
+	case StateQueued:
+		return "Queued"
+	case StateScheduled:
+		return "Scheduled"
+	case StateFired:
+		return "Fired"
+	case StateCanceled:
+		return "Canceled"
+	case StateDropped:
+		return "Dropped"
+	case StateRecycled:
+		return "Recycled"
+	default:
+		return "Unknown"
+	}
 }
 
+// Runnable 可调度的任务
+// 调用方复用实现了 Runnable 的对象时，配合对象池添加任务无需任何堆分配
+type Runnable interface {
+	Run()
+}
+
+// entryID 全局自增的条目编号
+var entryID atomic.Uint64
+
 // Entry 定时任务条目（同时作为队列节点）
 type Entry struct {
 	// 队列链接（热路径，放前面）
@@ -25,104 +75,558 @@ type Entry struct {
 	// 定时任务数据
 	expireAt time.Time
 	callback func()
-	removed  atomic.Bool
+	runnable Runnable
+	state    atomic.Uint64 // 代数 | 标志位 | EntryState
+
+	// Refresh 支持：原始延迟与被推迟后的截止时间（UnixNano）
+	delay     time.Duration
+	refreshAt atomic.Int64
+	snoozed   atomic.Int64 // Snooze 累计的推迟时长
+
+	// 元数据
+	id       uint64
+	tag      string
+	critical bool            // 关键任务，到期前由忙等阶段精确触发
+	handler  func(*Entry)    // 非空时代替 Timer 的 handler 处理该条目
+	firedAt  int64           // 交给 handler 的时间（UnixNano），仅 WithFireTimestamps 开启时记录
+	ctx      context.Context // 调度时的上下文（追踪信息等），由 AddChild 派生的任务继承
+
+	// 所属定时器，供 AddRepeating 重新放入与 AddChild 派生任务使用；重复间隔与剩余重复次数（-1 表示不限）
+	owner    *Timer
+	interval time.Duration
+	repeats  int64
 }
 
 // NewEntry 创建新的定时任务条目
+// 返回的指针由调用方持有，条目只在调用 Release 后回收复用
 func NewEntry(expireAt time.Time, callback func()) *Entry {
-	e := entryPool.Get().(*Entry)
+	e := getEntry()
+	e.reset(expireAt, callback)
+	return e
+}
+
+// reset 以新代数重新初始化条目
+func (e *Entry) reset(expireAt time.Time, callback func()) {
 	e.expireAt = expireAt
 	e.callback = callback
-	e.next = settingNext // 标记正在设置
-	e.removed.Store(false)
+	e.runnable = nil
+	e.id = entryID.Add(1)
+	e.tag = ""
+	e.critical = false
+	e.handler = nil
+	e.firedAt = 0
+	e.ctx = nil
+	e.owner = nil
+	e.repeats = 0
+	e.next = nil
+	e.state.Store((e.state.Load()>>genShift+1)<<genShift | flagExposed | uint64(StateQueued))
+	e.delay = 0
+	e.refreshAt.Store(0)
+	e.snoozed.Store(0)
+}
+
+// newUnpooledEntry 直接分配条目，由 GC 回收
+func newUnpooledEntry(expireAt time.Time, callback func()) *Entry {
+	e := &Entry{
+		expireAt: expireAt,
+		callback: callback,
+		id:       entryID.Add(1),
+	}
+	e.state.Store(1<<genShift | flagNoPool | uint64(StateQueued))
 	return e
 }
 
-// Release 释放回对象池
+// Release 释放回对象池，尚未触发的条目会先被取消
+// 条目仍在定时器中时延迟到移出时间轮后再回收，调用后不应再使用该指针
 func (e *Entry) Release() {
+	e.Cancel()
+	e.releaseSlot()
+	e.setFlag(flagReleased)
+	e.tryRecycle()
+}
+
+// Execute 执行回调，每个条目至多执行一次
+// 仅以 EntryRef 交给调用方的条目执行完成后自动回收；调用方持有 *Entry 的条目需调用 Release 才会回收
+func (e *Entry) Execute() {
+	e.execute(e.middleware())
+}
+
+// execute 按 Execute 的协议触发条目：转为已触发、经 run 执行、处理重复与回收
+// run 为已组装好的中间件链，EventBus 以投递事件代替最内层的回调
+func (e *Entry) execute(run EntryHandler) {
+	fired := e.transit(StateFired)
+	if fired {
+		run(e)
+	}
+	e.releaseSlot()
+	if fired {
+		if e.repeats != 0 && e.rearm() {
+			return
+		}
+		e.notifyDone()
+	}
+	e.autoRelease()
+	e.tryRecycle()
+}
+
+// middleware 返回所属定时器注册的中间件链，未注册时返回 runEntry
+func (e *Entry) middleware() EntryHandler {
+	if e.owner != nil {
+		if chain := e.owner.chain.Load(); chain != nil {
+			return *chain
+		}
+	}
+	return runEntry
+}
+
+// autoRelease 定时器用完条目后请求释放
+// 调用方仍持有 *Entry 的条目不自动释放，旧指针上的 Cancel、Refresh 等操作不会作用到复用后的其他任务
+func (e *Entry) autoRelease() {
+	if e.state.Load()&flagExposed == 0 {
+		e.setFlag(flagReleased)
+	}
+}
+
+// hide 标记条目只以 EntryRef 交给调用方，执行或取消后可自动回收，只在入队前调用
+func (e *Entry) hide() {
+	e.state.Store(e.state.Load() &^ flagExposed)
+}
+
+// detach 由定时器调用，表示不再引用该条目，返回是否已请求释放
+func (e *Entry) detach() bool {
+	return e.setFlag(flagDetached)&flagReleased != 0
+}
+
+// setFlag 设置标志位，返回设置前的状态字
+func (e *Entry) setFlag(flag uint64) uint64 {
+	for {
+		v := e.state.Load()
+		if v&flag != 0 || e.state.CompareAndSwap(v, v|flag) {
+			return v
+		}
+	}
+}
+
+// tryRecycle 定时器不再引用且已请求释放时放回对象池，每代至多一次
+func (e *Entry) tryRecycle() {
+	for {
+		v := e.state.Load()
+		if v&(flagDetached|flagReleased) != flagDetached|flagReleased || v&(flagPooled|flagNoPool) != 0 {
+			return
+		}
+		if e.state.CompareAndSwap(v, v|flagPooled) {
+			break
+		}
+	}
 	e.callback = nil
+	e.runnable = nil
+	e.tag = ""
+	e.handler = nil
+	e.ctx = nil
+	e.owner = nil
 	e.next = nil
-	entryPool.Put(e)
+	putEntry(e)
 }
 
-// Execute 执行回调
-func (e *Entry) Execute() {
-	if !e.removed.Load() && e.callback != nil {
-		e.callback()
+// Cancel 取消定时任务，返回是否在触发前成功取消
+func (e *Entry) Cancel() bool {
+	return e.transit(StateCanceled)
+}
+
+// Refresh 将截止时间重置为 now+原始延迟 - O(1)
+// 仅记录新的截止时间，任务到期时由 run loop 惰性重新放入时间轮，
+// 适合同一任务被高频刷新的空闲超时场景；run loop 已决定触发后返回 false
+func (e *Entry) Refresh() bool {
+	return e.refresh(e.state.Load() >> genShift)
+}
+
+func (e *Entry) refresh(gen uint64) bool {
+	if !e.beginDefer(gen) {
+		return false
+	}
+	now := time.Now()
+	if e.owner != nil {
+		now = e.owner.now()
+	}
+	e.refreshAt.Store(now.Add(e.delay).UnixNano())
+	e.endDefer()
+	return true
+}
+
+// Snooze 将未触发任务的截止时间再推迟 d，多次调用累计 - O(1)
+// 与 Refresh 相同，任务到期时由 run loop 惰性重新放入时间轮；返回 true 时推迟一定生效
+func (e *Entry) Snooze(d time.Duration) bool {
+	if d <= 0 || !e.beginDefer(e.state.Load()>>genShift) {
+		return false
+	}
+	e.snoozed.Add(int64(d))
+	e.endDefer()
+	return true
+}
+
+// beginDefer 以一次 CAS 确认条目仍待触发、run loop 尚未决定触发，并标记正在推迟
+// 推迟标记与 run loop 的 holdDefer 互斥：返回 true 的推迟一定被到期判断看到，返回 false 时条目按原时间触发
+func (e *Entry) beginDefer(gen uint64) bool {
+	for {
+		v := e.state.Load()
+		if v>>genShift != gen || v&flagSealed != 0 {
+			return false
+		}
+		if s := EntryState(v & stateMask); s != StateQueued && s != StateScheduled {
+			return false
+		}
+		if v&flagDefer != 0 {
+			runtime.Gosched()
+			continue
+		}
+		if e.state.CompareAndSwap(v, v|flagDefer) {
+			return true
+		}
+	}
+}
+
+func (e *Entry) endDefer() {
+	e.clearFlag(flagDefer)
+}
+
+// holdDefer 由 run loop 在取出推迟前调用，取得推迟标记，期间的 Refresh 与 Snooze 等待判断结束
+// 进行中的推迟记录很短，等待其完成即可
+func (e *Entry) holdDefer() {
+	for {
+		v := e.state.Load()
+		if v&flagDefer != 0 {
+			runtime.Gosched()
+			continue
+		}
+		if e.state.CompareAndSwap(v, v|flagDefer) {
+			return
+		}
+	}
+}
+
+// seal 在 holdDefer 之后确定触发条目，释放推迟标记，之后的 Refresh 与 Snooze 返回 false
+func (e *Entry) seal() {
+	for {
+		v := e.state.Load()
+		if e.state.CompareAndSwap(v, v&^flagDefer|flagSealed) {
+			return
+		}
+	}
+}
+
+// clearFlag 清除标志位
+func (e *Entry) clearFlag(flag uint64) {
+	for {
+		v := e.state.Load()
+		if v&flag == 0 || e.state.CompareAndSwap(v, v&^flag) {
+			return
+		}
+	}
+}
+
+// takeDeadline 取出 Refresh 与 Snooze 记录的新截止时间，仅由持有条目的定时器调用
+// 没有推迟时返回 false
+func (e *Entry) takeDeadline() (time.Time, bool) {
+	return e.postponed(e.refreshAt.Swap(0), e.snoozed.Swap(0))
+}
+
+// deadline 返回计入尚未处理的 Refresh 与 Snooze 后的截止时间
+func (e *Entry) deadline() time.Time {
+	if at, ok := e.postponed(e.refreshAt.Load(), e.snoozed.Load()); ok {
+		return at
 	}
+	return e.expireAt
 }
 
-// Cancel 取消定时任务
-func (e *Entry) Cancel() {
-	e.removed.Store(true)
+func (e *Entry) postponed(at, snoozed int64) (time.Time, bool) {
+	if snoozed > 0 {
+		at = max(at, e.expireAt.UnixNano()) + snoozed
+	}
+	if at <= e.expireAt.UnixNano() {
+		return time.Time{}, false
+	}
+	return time.Unix(0, at), true
+}
+
+// State 获取当前状态
+func (e *Entry) State() EntryState {
+	return EntryState(e.state.Load() & stateMask)
+}
+
+// IsDone 检查是否已结束（已触发、已取消或已丢弃）
+func (e *Entry) IsDone() bool {
+	s := e.State()
+	return s != StateQueued && s != StateScheduled
+}
+
+// transit 从待触发状态（Queued/Scheduled）迁移到 to
+func (e *Entry) transit(to EntryState) bool {
+	return e.transitGen(e.state.Load()>>genShift, to)
+}
+
+// transitGen 仅当代数匹配时从待触发状态迁移到 to
+func (e *Entry) transitGen(gen uint64, to EntryState) bool {
+	for {
+		v := e.state.Load()
+		if v>>genShift != gen {
+			return false
+		}
+		if s := EntryState(v & stateMask); s != StateQueued && s != StateScheduled {
+			return false
+		}
+		if e.state.CompareAndSwap(v, v&^stateMask|uint64(to)) {
+			if v&flagSpilled != 0 && to == StateCanceled {
+				e.owner.spill.cancel(e.id)
+			}
+			// 触发的条目在回调执行完成后才通知
+			if to == StateCanceled || to == StateDropped {
+				e.notifyDone()
+			}
+			return true
+		}
+	}
+}
+
+// EntryRef 带代数校验的条目引用
+// AddEntryRef 等只返回 EntryRef 的条目执行后自动回收复用，条目被复用后对旧引用的操作均无效
+type EntryRef struct {
+	entry *Entry
+	gen   uint64
+}
+
+// Ref 获取当前代数的条目引用
+func (e *Entry) Ref() EntryRef {
+	return EntryRef{entry: e, gen: e.state.Load() >> genShift}
+}
+
+// Valid 检查引用的条目是否尚未被复用
+func (r EntryRef) Valid() bool {
+	return r.entry != nil && r.entry.state.Load()>>genShift == r.gen
+}
+
+// Cancel 取消定时任务，条目已被复用时返回 false
+func (r EntryRef) Cancel() bool {
+	return r.entry != nil && r.entry.transitGen(r.gen, StateCanceled)
+}
+
+// Refresh 重置截止时间，条目已被复用时返回 false
+func (r EntryRef) Refresh() bool {
+	return r.entry != nil && r.entry.refresh(r.gen)
+}
+
+// State 获取状态，条目已被复用时返回 StateRecycled
+func (r EntryRef) State() EntryState {
+	if r.entry == nil {
+		return StateRecycled
+	}
+	v := r.entry.state.Load()
+	if v>>genShift != r.gen {
+		return StateRecycled
+	}
+	return EntryState(v & stateMask)
+}
+
+// ID 获取条目编号
+func (e *Entry) ID() uint64 {
+	return e.id
+}
+
+// Tag 获取条目标签
+func (e *Entry) Tag() string {
+	return e.tag
+}
+
+// Context 获取调度时附带的上下文，未附带时返回 context.Background()
+func (e *Entry) Context() context.Context {
+	if e.ctx == nil {
+		return context.Background()
+	}
+	return e.ctx
+}
+
+// Critical 检查是否为关键任务
+func (e *Entry) Critical() bool {
+	return e.critical
+}
+
+// ExpireAt 获取过期时间，已计入尚未生效的 Refresh 与 Snooze
+func (e *Entry) ExpireAt() time.Time {
+	return e.deadline()
+}
+
+// FiredAt 获取实际交给 handler 的时间，未开启 WithFireTimestamps 或尚未触发时返回零值
+func (e *Entry) FiredAt() time.Time {
+	if e.firedAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, e.firedAt)
+}
+
+// Lateness 获取实际触发时间晚于过期时间的时长，可在 handler 或回调中据此跳过已失去意义的工作
+// 未开启 WithFireTimestamps 或尚未触发时返回 0
+func (e *Entry) Lateness() time.Duration {
+	if e.firedAt == 0 {
+		return 0
+	}
+	return time.Duration(e.firedAt - e.expireAt.UnixNano())
 }
 
 // IsCanceled 检查是否已取消
 func (e *Entry) IsCanceled() bool {
-	return e.removed.Load()
+	return e.State() == StateCanceled
 }
 
 // MPSCQueue Wait-Free MPSC队列
+// 采用 Vyukov 侵入式 MPSC 算法：生产者只做一次原子交换再链接前驱，
+// 消费者遇到尚未完成链接的节点时直接返回，由下一轮继续处理，永不自旋等待
 type MPSCQueue struct {
-	head unsafe.Pointer // *Entry
-	_    [56]byte       // padding
+	head unsafe.Pointer          // *Entry，生产者端
+	_    [cacheLineSize - 8]byte // padding
+	tail unsafe.Pointer          // *Entry，消费者端
+	stub Entry
 }
 
 // NewMPSCQueue 创建队列
 func NewMPSCQueue() *MPSCQueue {
-	return &MPSCQueue{}
+	q := &MPSCQueue{}
+	q.head = unsafe.Pointer(&q.stub)
+	q.tail = unsafe.Pointer(&q.stub)
+	return q
+}
+
+// checkPushable 调试模式下检查条目是否可以入队
+// 已释放或重复添加的条目会破坏时间轮链表，直接 panic 以便定位
+func checkPushable(entry *Entry) {
+	v := entry.setFlag(flagPushed)
+	switch {
+	case v&flagPooled != 0:
+		panic("whTimer: push of released Entry (use after Release)")
+	case v&flagPushed != 0:
+		panic("whTimer: Entry pushed more than once")
+	}
 }
 
 // Push 添加元素 - Wait-Free O(1)
 func (q *MPSCQueue) Push(entry *Entry) bool {
-	oldHead := atomic.SwapPointer(&q.head, unsafe.Pointer(entry))
-	atomic.StorePointer(&entry.next, oldHead)
-	return oldHead == nil
+	return q.PushChain(entry, entry)
 }
 
-// PopAll 取出所有元素 - Wait-Free
-func (q *MPSCQueue) PopAll() *Entry {
-	head := (*Entry)(atomic.SwapPointer(&q.head, nil))
-	if head == nil {
-		return nil
+// PushChain 以一次原子交换添加预先链接好的链表 - Wait-Free O(1)
+// 链表需通过 next 由 head 指向 tail，出队顺序为 head 在前、tail 在后
+func (q *MPSCQueue) PushChain(head, tail *Entry) bool {
+	if debugChecks {
+		for e := head; ; e = getNext(e) {
+			checkPushable(e)
+			if e == tail {
+				break
+			}
+		}
 	}
+	atomic.StorePointer(&tail.next, nil)
+	prev := atomic.SwapPointer(&q.head, unsafe.Pointer(tail))
+	atomic.StorePointer(&(*Entry)(prev).next, unsafe.Pointer(head))
+	return prev == unsafe.Pointer(&q.stub)
+}
 
-	// 反转链表
-	var prev *Entry
-	curr := head
+// pop 取出一个元素，队列为空或队首生产者尚未完成链接时返回 nil
+func (q *MPSCQueue) pop() *Entry {
+	stub := &q.stub
+	tail := (*Entry)(atomic.LoadPointer(&q.tail))
+	next := getNext(tail)
 
-	for curr != nil {
-		var next unsafe.Pointer
-		for {
-			next = atomic.LoadPointer(&curr.next)
-			if next != settingNext {
-				break
-			}
+	if tail == stub {
+		if next == nil {
+			return nil
 		}
+		atomic.StorePointer(&q.tail, unsafe.Pointer(next))
+		tail = next
+		next = getNext(next)
+	}
 
-		atomic.StorePointer(&curr.next, unsafe.Pointer(prev))
-		prev = curr
-		curr = (*Entry)(next)
+	if next != nil {
+		atomic.StorePointer(&q.tail, unsafe.Pointer(next))
+		return tail
+	}
+
+	// tail 不是最后一个节点，说明有生产者正在链接
+	if atomic.LoadPointer(&q.head) != unsafe.Pointer(tail) {
+		return nil
 	}
 
-	return prev
+	// tail 是最后一个节点，重新放入 stub 后才能取出
+	q.pushStub()
+	next = getNext(tail)
+	if next != nil {
+		atomic.StorePointer(&q.tail, unsafe.Pointer(next))
+		return tail
+	}
+	return nil
+}
+
+func (q *MPSCQueue) pushStub() {
+	stub := &q.stub
+	atomic.StorePointer(&stub.next, nil)
+	prev := atomic.SwapPointer(&q.head, unsafe.Pointer(stub))
+	atomic.StorePointer(&(*Entry)(prev).next, unsafe.Pointer(stub))
+}
+
+// PopAll 取出所有元素，返回按入队顺序通过 next 链接的链表
+func (q *MPSCQueue) PopAll() *Entry {
+	var head, last *Entry
+	for entry := q.pop(); entry != nil; entry = q.pop() {
+		setNext(entry, nil)
+		if last == nil {
+			head = entry
+		} else {
+			setNext(last, entry)
+		}
+		last = entry
+	}
+	return head
 }
 
 // DrainAll 取出并处理所有元素
 func (q *MPSCQueue) DrainAll(fn func(*Entry)) int {
-	head := q.PopAll()
 	count := 0
-	for head != nil {
-		// 必须先保存next，因为fn可能修改head.next（如添加到wheel链表）
-		next := (*Entry)(atomic.LoadPointer(&head.next))
-		fn(head)
-		head = next
+	for entry := q.pop(); entry != nil; entry = q.pop() {
+		fn(entry)
+		count++
+	}
+	return count
+}
+
+// DrainN 取出并处理至多 n 个元素，生产者持续写入时也能及时返回
+func (q *MPSCQueue) DrainN(n int, fn func(*Entry)) int {
+	count := 0
+	for count < n {
+		entry := q.pop()
+		if entry == nil {
+			break
+		}
+		fn(entry)
 		count++
 	}
 	return count
 }
 
+// peek 按入队顺序只读遍历队列中的元素，不取出，仅由消费者调用
+// 只遍历开始时已入队的元素，遇到生产者尚未完成链接的元素时提前停止；生产者持续入队时也能返回
+func (q *MPSCQueue) peek(fn func(*Entry)) {
+	stub := &q.stub
+	last := (*Entry)(atomic.LoadPointer(&q.head))
+	for e := (*Entry)(atomic.LoadPointer(&q.tail)); e != nil; e = getNext(e) {
+		if e != stub {
+			fn(e)
+		}
+		if e == last {
+			return
+		}
+	}
+}
+
 // IsEmpty 检查队列是否为空
+// 由消费者调用时，返回 false 表示仍有元素（可能正在被生产者链接）需要处理
 func (q *MPSCQueue) IsEmpty() bool {
-	return atomic.LoadPointer(&q.head) == nil
+	tail := (*Entry)(atomic.LoadPointer(&q.tail))
+	return tail == &q.stub && getNext(tail) == nil
 }
@@ -0,0 +1,137 @@
+package whTimer
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Event 定时任务触发事件
+type Event struct {
+	ID       uint64
+	Tag      string
+	Deadline time.Time // 任务截止时间
+	FiredAt  time.Time // 实际触发时间
+}
+
+// EventBus 将到期条目按标签投递为事件，代替执行回调
+// 作为 handler 传给 NewTimer 或 Runner.NewTimer，订阅方在自己的 select 循环中消费事件:
+//
+//	bus := NewEventBus()
+//	timer := NewTimer(bus.Handle)
+//	events, cancel := bus.Subscribe("email", 64)
+//
+// 标签无订阅者的条目照常执行回调；订阅 "" 接收所有条目
+// 投递不阻塞 run loop，订阅通道已满时丢弃事件并计入 Dropped
+type EventBus struct {
+	mu      sync.RWMutex
+	subs    map[string][]chan Event
+	dropped atomic.Uint64
+	cached  atomic.Pointer[busChain]
+
+	publishFn EntryHandler // 预先绑定的 publish，避免每次触发分配方法值
+}
+
+// NewEventBus 创建事件总线
+func NewEventBus() *EventBus {
+	b := &EventBus{subs: make(map[string][]chan Event)}
+	b.publishFn = b.publish
+	return b
+}
+
+// Subscribe 订阅标签为 topic 的触发事件，返回事件通道与取消函数
+// 取消后通道被关闭
+func (b *EventBus) Subscribe(topic string, buffer int) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+	b.mu.Lock()
+	b.subs[topic] = append(b.subs[topic], ch)
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			subs := b.subs[topic]
+			for i, c := range subs {
+				if c == ch {
+					subs = append(subs[:i], subs[i+1:]...)
+					break
+				}
+			}
+			if len(subs) == 0 {
+				delete(b.subs, topic)
+			} else {
+				b.subs[topic] = subs
+			}
+			close(ch)
+		})
+	}
+}
+
+// Handle 处理到期条目，可直接用作定时器的 handler
+// 与 Execute 相同地经过中间件链并处理重复，只是以投递事件代替执行回调
+func (b *EventBus) Handle(e *Entry) {
+	e.execute(b.chain(e.owner))
+}
+
+// busChain 缓存以 publish 为最内层组装的中间件链，base 为组装时定时器的中间件链
+type busChain struct {
+	owner *Timer
+	base  *EntryHandler
+	run   EntryHandler
+}
+
+// chain 返回 owner 的中间件链包装 publish 后的结果，中间件变化后重新组装
+func (b *EventBus) chain(owner *Timer) EntryHandler {
+	if owner == nil {
+		return b.publishFn
+	}
+	base := owner.chain.Load()
+	if base == nil {
+		return b.publishFn
+	}
+	if c := b.cached.Load(); c != nil && c.owner == owner && c.base == base {
+		return c.run
+	}
+	c := &busChain{owner: owner, base: base, run: owner.wrap(b.publishFn)}
+	b.cached.Store(c)
+	return c.run
+}
+
+// publish 投递条目的触发事件，标签无订阅者时执行回调
+// 回调在锁外执行，可在其中订阅或取消订阅；投递为非阻塞发送，在读锁内完成以免发往取消时已关闭的通道
+func (b *EventBus) publish(e *Entry) {
+	b.mu.RLock()
+	topic, all := b.subs[e.tag], b.subs[""]
+	if e.tag == "" {
+		topic = nil
+	}
+	if len(topic) == 0 && len(all) == 0 {
+		b.mu.RUnlock()
+		runEntry(e)
+		return
+	}
+	ev := Event{ID: e.id, Tag: e.tag, Deadline: e.expireAt, FiredAt: time.Now()}
+	if e.owner != nil {
+		ev.FiredAt = e.owner.now()
+	}
+	b.deliver(topic, ev)
+	b.deliver(all, ev)
+	b.mu.RUnlock()
+}
+
+func (b *EventBus) deliver(subs []chan Event, ev Event) {
+	for _, ch := range subs {
+		select {
+		case ch <- ev:
+		default:
+			b.dropped.Add(1)
+		}
+	}
+}
+
+// Dropped 返回因订阅通道已满而丢弃的事件数
+func (b *EventBus) Dropped() uint64 {
+	return b.dropped.Load()
+}
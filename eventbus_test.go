@@ -0,0 +1,99 @@
+package whTimer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventBus(t *testing.T) {
+	bus := NewEventBus()
+	timer := NewTimer(bus.Handle)
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	email, cancelEmail := bus.Subscribe("email", 4)
+	all, cancelAll := bus.Subscribe("", 1)
+	defer cancelAll()
+
+	var ran atomic.Int32
+	sent := timer.AddTaggedEntry("email", 100*time.Millisecond, func() { ran.Add(1) })
+	id := sent.ID()
+	timer.AddTaggedEntry("other", 200*time.Millisecond, func() { ran.Add(1) })
+	timer.Advance(base.Add(time.Second))
+
+	select {
+	case ev := <-email:
+		if ev.ID != id || ev.Tag != "email" || !ev.Deadline.Equal(base.Add(100*time.Millisecond)) {
+			t.Fatalf("unexpected event %+v", ev)
+		}
+	default:
+		t.Fatal("no email event")
+	}
+	// 通配订阅的缓冲只有 1，第二个事件被丢弃
+	if ev := <-all; ev.ID != id || bus.Dropped() != 1 {
+		t.Fatalf("event=%+v dropped=%d", ev, bus.Dropped())
+	}
+	if ran.Load() != 0 {
+		t.Fatalf("callbacks ran %d times", ran.Load())
+	}
+
+	cancelEmail()
+	if _, ok := <-email; ok {
+		t.Fatal("channel not closed after cancel")
+	}
+	cancelAll()
+	timer.AddTaggedEntry("email", 100*time.Millisecond, func() { ran.Add(1) })
+	timer.Advance(base.Add(2 * time.Second))
+	if ran.Load() != 1 {
+		t.Fatalf("unsubscribed entry should execute, ran=%d", ran.Load())
+	}
+}
+
+func TestEventBusExecute(t *testing.T) {
+	bus := NewEventBus()
+	timer := NewTimer(bus.Handle)
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	var wrapped atomic.Int32
+	timer.Use(func(next EntryHandler) EntryHandler {
+		return func(e *Entry) {
+			wrapped.Add(1)
+			next(e)
+		}
+	})
+	all, cancel := bus.Subscribe("", 8)
+	defer cancel()
+
+	// 重复条目每次都投递事件，事件时间取自定时器时钟，且都经过中间件
+	timer.AddRepeating(100*time.Millisecond, 100*time.Millisecond, 3, func() { t.Error("callback ran") })
+	timer.Advance(base.Add(time.Second))
+	if len(all) != 3 || wrapped.Load() != 3 {
+		t.Fatalf("events=%d wrapped=%d, want 3", len(all), wrapped.Load())
+	}
+	if ev := <-all; !ev.FiredAt.Equal(base.Add(time.Second)) || !ev.Deadline.Equal(base.Add(100*time.Millisecond)) {
+		t.Fatalf("event=%+v", ev)
+	}
+	for len(all) > 0 {
+		<-all
+	}
+
+	// 无订阅者时执行回调，回调中订阅不会与投递互相等待
+	cancel()
+	done := make(chan struct{})
+	timer.AddTaggedEntry("late", 0, func() {
+		_, unsub := bus.Subscribe("late", 1)
+		unsub()
+		close(done)
+	})
+	timer.Advance(base.Add(2 * time.Second))
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("callback subscribing to the bus deadlocked")
+	}
+	if wrapped.Load() != 4 {
+		t.Fatalf("wrapped=%d, want 4", wrapped.Load())
+	}
+}
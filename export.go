@@ -0,0 +1,106 @@
+//go:build !whtimer_tiny && !tinygo
+
+package whTimer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ExportFormat 调度导出格式
+type ExportFormat int
+
+const (
+	ExportJSON    ExportFormat = iota // JSON 文档
+	ExportCrontab                     // 秒级 crontab，格式同 Cron
+)
+
+// ExportedCron 导出的周期任务
+type ExportedCron struct {
+	Spec string    `json:"spec,omitempty"` // 自定义规则为空
+	Next time.Time `json:"next"`
+}
+
+// ExportedOnce 导出的一次性任务
+type ExportedOnce struct {
+	ID  uint64    `json:"id"`
+	Tag string    `json:"tag,omitempty"`
+	At  time.Time `json:"at"`
+}
+
+// ExportedSchedules 调度导出内容
+type ExportedSchedules struct {
+	Crons []ExportedCron `json:"crons"`
+	Once  []ExportedOnce `json:"once"`
+}
+
+// ExportSchedules 导出登记的周期任务与待触发的一次性任务，供审计与迁移工具使用
+// 周期任务当前待触发的条目不重复导出为一次性任务；已取消的条目不导出
+func (t *Timer) ExportSchedules(format ExportFormat) ([]byte, error) {
+	s := t.exportSchedules()
+	switch format {
+	case ExportJSON:
+		return json.MarshalIndent(s, "", "  ")
+	case ExportCrontab:
+		return s.crontab(), nil
+	default:
+		return nil, fmt.Errorf("whTimer: unknown export format %d", format)
+	}
+}
+
+func (t *Timer) exportSchedules() ExportedSchedules {
+	s := ExportedSchedules{Crons: []ExportedCron{}, Once: []ExportedOnce{}}
+	owned := make(map[uint64]struct{})
+	for _, c := range t.Crons() {
+		next := c.Next()
+		if next.IsZero() {
+			continue
+		}
+		owned[c.entryID.Load()] = struct{}{}
+		s.Crons = append(s.Crons, ExportedCron{Spec: c.spec, Next: next})
+	}
+
+	add := func(e *Entry) {
+		if _, ok := owned[e.id]; ok || e.IsCanceled() {
+			return
+		}
+		s.Once = append(s.Once, ExportedOnce{ID: e.id, Tag: e.tag, At: e.expireAt})
+	}
+	t.inspect(func() {
+		t.walkPending(add)
+	})
+
+	slices.SortStableFunc(s.Crons, func(a, b ExportedCron) int {
+		return a.Next.Compare(b.Next)
+	})
+	slices.SortStableFunc(s.Once, func(a, b ExportedOnce) int {
+		return a.At.Compare(b.At)
+	})
+	return s
+}
+
+// crontab 每行一个任务，一次性任务以精确到秒的日期表达式输出并附 "# once" 注释，
+// 无法用表达式描述的自定义规则整行注释输出
+func (s ExportedSchedules) crontab() []byte {
+	var buf bytes.Buffer
+	for _, c := range s.Crons {
+		next := c.Next.Format(time.RFC3339)
+		if c.Spec == "" {
+			fmt.Fprintf(&buf, "# custom schedule, next %s\n", next)
+			continue
+		}
+		fmt.Fprintf(&buf, "%s # next %s\n", c.Spec, next)
+	}
+	for _, o := range s.Once {
+		at := o.At.Round(time.Second)
+		fmt.Fprintf(&buf, "%d %d %d %d %d * # once id=%d", at.Second(), at.Minute(), at.Hour(), at.Day(), int(at.Month()), o.ID)
+		if o.Tag != "" {
+			fmt.Fprintf(&buf, " tag=%s", o.Tag)
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,59 @@
+//go:build !whtimer_tiny && !tinygo
+
+package whTimer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimerExportSchedules(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	c, err := timer.Cron("0 30 9 * * 1-5", func() {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Stop()
+	iv := timer.CronInterval(time.Hour, func() {})
+	defer iv.Stop()
+	at := time.Date(2030, 1, 2, 3, 4, 5, 0, time.Local)
+	timer.AddTaggedEntryAt("report", at, func() {})
+	timer.AddEntry(time.Hour, func() {}).Cancel()
+
+	data, err := timer.ExportSchedules(ExportJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s ExportedSchedules
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	specs := map[string]bool{}
+	for _, c := range s.Crons {
+		specs[c.Spec] = true
+	}
+	if len(s.Crons) != 2 || !specs["@every 1h0m0s"] || !specs["0 30 9 * * 1-5"] {
+		t.Fatalf("crons=%+v", s.Crons)
+	}
+	if len(s.Once) != 1 || s.Once[0].Tag != "report" || !s.Once[0].At.Equal(at) {
+		t.Fatalf("once=%+v", s.Once)
+	}
+
+	data, err = timer.ExportSchedules(ExportCrontab)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "5 4 3 2 1 * # once id=") || !strings.Contains(string(data), "0 30 9 * * 1-5 # next ") {
+		t.Fatalf("crontab:\n%s", data)
+	}
+
+	iv.Stop()
+	if s := timer.exportSchedules(); len(s.Crons) != 1 {
+		t.Fatalf("stopped cron still exported: %+v", s.Crons)
+	}
+}
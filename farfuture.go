@@ -0,0 +1,92 @@
+package whTimer
+
+import (
+	"sync"
+	"time"
+)
+
+// compactEntry 远期任务的紧凑表示：截止时间、标签（即 Handle 注册的 handler 名）与数据
+// 约为完整 Entry 的三分之一，且不持有闭包
+type compactEntry struct {
+	deadline int64
+	id       uint64
+	tag      string
+	payload  []byte
+}
+
+// MemoryStore 在内存中以紧凑结构保存远期任务的 Store，按截止时间组织为与 entryHeap 相同的 4 叉小顶堆
+type MemoryStore struct {
+	mu   sync.Mutex
+	heap []compactEntry
+}
+
+// NewMemoryStore 创建内存 Store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// compactBefore 按截止时间与编号排序，作为 MemoryStore 的堆序
+func compactBefore(a, b compactEntry) bool {
+	if a.deadline != b.deadline {
+		return a.deadline < b.deadline
+	}
+	return a.id < b.id
+}
+
+// Put 实现 Store
+func (s *MemoryStore) Put(e SpilledEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.heap = heapPush(s.heap, compactEntry{
+		deadline: e.Deadline.UnixNano(),
+		id:       e.ID,
+		tag:      e.Tag,
+		payload:  e.Payload,
+	}, compactBefore)
+	return nil
+}
+
+// Next 实现 Store
+func (s *MemoryStore) Next() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.heap) == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, s.heap[0].deadline), true
+}
+
+// TakeBefore 实现 Store
+func (s *MemoryStore) TakeBefore(deadline time.Time) ([]SpilledEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	limit := deadline.UnixNano()
+	var entries []SpilledEntry
+	for len(s.heap) > 0 && s.heap[0].deadline <= limit {
+		var c compactEntry
+		c, s.heap = heapPop(s.heap, compactBefore)
+		entries = append(entries, SpilledEntry{
+			ID:       c.id,
+			Tag:      c.tag,
+			Deadline: time.Unix(0, c.deadline),
+			Payload:  c.payload,
+		})
+	}
+	return entries, nil
+}
+
+// Len 实现 Store
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.heap)
+}
+
+// WithCompactFarFuture 截止时间在 horizon 之后的可序列化任务以紧凑结构保存在内存中，
+// 进入 horizon 内时才创建完整条目放入时间轮，可大幅降低大量远期任务的内存占用
+// 只有 AddSerializable 添加的任务会被压缩；与 WithSpill 共用同一机制，同时设置时以最后一个为准
+func WithCompactFarFuture(horizon time.Duration) Option {
+	return WithSpill(NewMemoryStore(), 0, horizon, nil)
+}
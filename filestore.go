@@ -0,0 +1,211 @@
+//go:build !whtimer_tiny && !tinygo
+
+package whTimer
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// spillRecord 溢出文件中的一行：任务记录或删除标记
+type spillRecord struct {
+	Del      uint64 `json:"del,omitempty"`
+	ID       uint64 `json:"id,omitempty"`
+	Tag      string `json:"tag,omitempty"`
+	Deadline int64  `json:"deadline,omitempty"`
+	Payload  []byte `json:"payload,omitempty"`
+}
+
+// spillIndex 内存中只保留截止时间与记录在文件中的位置
+type spillIndex struct {
+	deadline int64
+	id       uint64
+	off      int64
+	n        int
+}
+
+// FileStore 基于本地追加写文件的 Store
+// 内存中每条记录只占一个索引项，取出的记录以删除标记表示，全部取出后截断文件；
+// 重新打开同一文件时恢复尚未取出的记录，可在重启后继续调度
+type FileStore struct {
+	mu    sync.Mutex
+	f     *os.File
+	size  int64
+	index []spillIndex // 按截止时间升序
+}
+
+// OpenFileStore 打开或创建溢出文件
+func OpenFileStore(path string) (*FileStore, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	s := &FileStore{f: f}
+	if err := s.load(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// load 扫描文件重建索引，末尾不完整的一行视为写入中断而丢弃
+func (s *FileStore) load() error {
+	live := make(map[uint64]spillIndex)
+	r := bufio.NewReader(s.f)
+	var off int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		var rec spillRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return err
+		}
+		if rec.Del != 0 {
+			delete(live, rec.Del)
+		} else {
+			live[rec.ID] = spillIndex{deadline: rec.Deadline, id: rec.ID, off: off, n: len(line)}
+		}
+		off += int64(len(line))
+	}
+
+	s.size = off
+	if err := s.f.Truncate(off); err != nil {
+		return err
+	}
+	for _, idx := range live {
+		s.index = append(s.index, idx)
+	}
+	slices.SortFunc(s.index, compareSpillIndex)
+	return s.compactIfEmpty()
+}
+
+func compareSpillIndex(a, b spillIndex) int {
+	if a.deadline != b.deadline {
+		return cmp.Compare(a.deadline, b.deadline)
+	}
+	return cmp.Compare(a.id, b.id)
+}
+
+// append 追加一行，返回其偏移与长度
+func (s *FileStore) append(rec spillRecord) (int64, int, error) {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return 0, 0, err
+	}
+	line = append(line, '\n')
+	off := s.size
+	if _, err := s.f.WriteAt(line, off); err != nil {
+		return 0, 0, err
+	}
+	s.size += int64(len(line))
+	return off, len(line), nil
+}
+
+// Put 实现 Store
+func (s *FileStore) Put(e SpilledEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec := spillRecord{ID: e.ID, Tag: e.Tag, Deadline: e.Deadline.UnixNano(), Payload: e.Payload}
+	off, n, err := s.append(rec)
+	if err != nil {
+		return err
+	}
+	idx := spillIndex{deadline: rec.Deadline, id: e.ID, off: off, n: n}
+	i, _ := slices.BinarySearchFunc(s.index, idx, compareSpillIndex)
+	s.index = slices.Insert(s.index, i, idx)
+	return nil
+}
+
+// Next 实现 Store
+func (s *FileStore) Next() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.index) == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, s.index[0].deadline), true
+}
+
+// TakeBefore 实现 Store
+func (s *FileStore) TakeBefore(deadline time.Time) ([]SpilledEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	limit := deadline.UnixNano()
+	n := 0
+	for n < len(s.index) && s.index[n].deadline <= limit {
+		n++
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	entries := make([]SpilledEntry, 0, n)
+	for _, idx := range s.index[:n] {
+		line := make([]byte, idx.n)
+		if _, err := s.f.ReadAt(line, idx.off); err != nil {
+			return nil, err
+		}
+		var rec spillRecord
+		if err := json.Unmarshal(bytes.TrimSpace(line), &rec); err != nil {
+			return nil, err
+		}
+		entries = append(entries, SpilledEntry{
+			ID:       rec.ID,
+			Tag:      rec.Tag,
+			Deadline: time.Unix(0, rec.Deadline),
+			Payload:  rec.Payload,
+		})
+	}
+
+	// 先写入全部删除标记再移出索引：写入失败时撤回已写的部分并保留记录，
+	// 避免重启后重复调度已取出的任务，或丢失未能取出的任务
+	size := s.size
+	for _, e := range entries {
+		if _, _, err := s.append(spillRecord{Del: e.ID}); err != nil {
+			s.size = size
+			return nil, errors.Join(err, s.f.Truncate(size))
+		}
+	}
+	s.index = slices.Delete(s.index, 0, n)
+	// 删除标记已落盘，截断失败只影响空间回收，取出的记录照常返回
+	return entries, s.compactIfEmpty()
+}
+
+// compactIfEmpty 没有记录时截断文件，回收删除标记占用的空间
+func (s *FileStore) compactIfEmpty() error {
+	if len(s.index) > 0 || s.size == 0 {
+		return nil
+	}
+	if err := s.f.Truncate(0); err != nil {
+		return err
+	}
+	s.size = 0
+	return nil
+}
+
+// Len 实现 Store
+func (s *FileStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.index)
+}
+
+// Close 关闭溢出文件，未取出的记录保留在文件中
+func (s *FileStore) Close() error {
+	return s.f.Close()
+}
@@ -0,0 +1,62 @@
+package whTimer
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// FirstOf 阻塞到 durations 中最先到期的一个，返回其下标；ctx 先结束时返回 -1
+// 每个候选只占用一个时间轮条目，返回时取消其余条目，适合"软超时后再硬超时"等竞速场景
+// 依赖 handler 调用 Execute
+func FirstOf(ctx context.Context, t *Timer, durations ...time.Duration) int {
+	return FirstOfChan(ctx, t, nil, durations...)
+}
+
+// FirstOfChan 同 FirstOf，另外让 chs 中的通道参与竞速
+// 通道 i 先就绪（可读或已关闭）时返回 i，durations[j] 先到期时返回 len(chs)+j，ctx 先结束时返回 -1
+func FirstOfChan(ctx context.Context, t *Timer, chs []<-chan struct{}, durations ...time.Duration) int {
+	fired := make(chan int, 1)
+	refs := make([]EntryRef, len(durations))
+	for i, d := range durations {
+		idx := len(chs) + i
+		_, refs[i] = t.addEntryRef(t.now().Add(d), func() {
+			select {
+			case fired <- idx:
+			default:
+			}
+		})
+	}
+	defer func() {
+		for _, ref := range refs {
+			ref.Cancel()
+		}
+	}()
+
+	if len(chs) == 0 {
+		select {
+		case i := <-fired:
+			return i
+		case <-ctx.Done():
+			return -1
+		}
+	}
+
+	cases := make([]reflect.SelectCase, 0, len(chs)+2)
+	for _, ch := range chs {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)})
+	}
+	cases = append(cases,
+		reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(fired)},
+		reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+	)
+	chosen, v, _ := reflect.Select(cases)
+	switch chosen {
+	case len(chs):
+		return int(v.Int())
+	case len(chs) + 1:
+		return -1
+	default:
+		return chosen
+	}
+}
@@ -0,0 +1,36 @@
+package whTimer
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFirstOf(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	ctx := context.Background()
+	if i := FirstOf(ctx, timer, 200*time.Millisecond, 5*time.Millisecond, time.Second); i != 1 {
+		t.Fatalf("FirstOf=%d, want 1", i)
+	}
+	if n := timer.Pending(); n != 2 {
+		t.Fatalf("Pending=%d, losers should remain as canceled entries", n)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if i := FirstOf(canceled, timer, time.Second); i != -1 {
+		t.Fatalf("FirstOf with canceled ctx=%d", i)
+	}
+
+	ready := make(chan struct{})
+	close(ready)
+	if i := FirstOfChan(ctx, timer, []<-chan struct{}{make(chan struct{}), ready}, time.Second); i != 1 {
+		t.Fatalf("FirstOfChan=%d, want channel 1", i)
+	}
+	if i := FirstOfChan(ctx, timer, []<-chan struct{}{make(chan struct{})}, time.Second, time.Millisecond); i != 2 {
+		t.Fatalf("FirstOfChan=%d, want duration index 2", i)
+	}
+}
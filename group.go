@@ -0,0 +1,48 @@
+package whTimer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Group 类似 errgroup.Group 的并发任务组，截止时间由时间轮条目负责
+// 扇出代码的超时统一放在共享的时间轮上，而不是每个组一个运行时定时器
+type Group struct {
+	cancel context.CancelCauseFunc
+	entry  EntryRef // 负责截止时间的时间轮条目
+
+	wg      sync.WaitGroup
+	errOnce sync.Once
+	err     error
+}
+
+// GroupWithDeadline 返回 d 后由时间轮取消 context 的任务组及其 context
+// 任一任务返回错误或 Wait 返回时 context 被取消；到期时 context.Cause 为 *DeadlineError
+func GroupWithDeadline(ctx context.Context, t *Timer, d time.Duration) (*Group, context.Context) {
+	at := t.now().Add(d)
+	gctx, cancel, ref := t.withWheelDeadline(ctx, at, t.deadlineCause(at))
+	return &Group{cancel: cancel, entry: ref}, gctx
+}
+
+// Go 在新的 goroutine 中执行 f，第一个非 nil 错误会取消组的 context
+func (g *Group) Go(f func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := f(); err != nil {
+			g.errOnce.Do(func() {
+				g.err = err
+				g.cancel(err)
+			})
+		}
+	}()
+}
+
+// Wait 等待所有任务返回，释放时间轮条目并返回第一个非 nil 错误
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.entry.Cancel()
+	g.cancel(g.err)
+	return g.err
+}
@@ -0,0 +1,38 @@
+package whTimer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGroupWithDeadline(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	g, ctx := GroupWithDeadline(context.Background(), timer, 20*time.Millisecond)
+	for range 3 {
+		g.Go(func() error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}
+	err := g.Wait()
+	var de *DeadlineError
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.As(context.Cause(ctx), &de) {
+		t.Fatalf("err=%v cause=%v", err, context.Cause(ctx))
+	}
+
+	boom := errors.New("boom")
+	g, ctx = GroupWithDeadline(context.Background(), timer, time.Hour)
+	g.Go(func() error { return boom })
+	g.Go(func() error {
+		<-ctx.Done()
+		return nil
+	})
+	if err := g.Wait(); err != boom || context.Cause(ctx) != boom {
+		t.Fatalf("err=%v cause=%v", err, context.Cause(ctx))
+	}
+}
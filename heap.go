@@ -0,0 +1,145 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// entryHeap 按到期时间排序的 4 叉小顶堆
+// 任务较少时代替时间轮，避免为几个超时分配 64 槽的多层轮
+type entryHeap []*Entry
+
+// dueBefore 按进入触发阶段的时间比较条目，作为 entryHeap 的排序
+func (t *Timer) dueBefore(a, b *Entry) bool {
+	return t.dueAt(a).Before(t.dueAt(b))
+}
+
+func (h *entryHeap) push(t *Timer, e *Entry) {
+	*h = heapPush(*h, e, t.dueBefore)
+}
+
+func (h *entryHeap) pop(t *Timer) *Entry {
+	var top *Entry
+	top, *h = heapPop(*h, t.dueBefore)
+	return top
+}
+
+// remove 移除下标 i 处的条目
+func (h *entryHeap) remove(t *Timer, i int) {
+	*h = heapRemove(*h, i, t.dueBefore)
+}
+
+// init 原地建堆
+func (h entryHeap) init(t *Timer) {
+	for i := (len(h) - 2) / 4; i >= 0; i-- {
+		heapDown(h, i, t.dueBefore)
+	}
+}
+
+// heapPush 等为 4 叉小顶堆的基本操作，由 entryHeap 与 MemoryStore 共用
+func heapPush[T any](h []T, x T, less func(a, b T) bool) []T {
+	h = append(h, x)
+	heapUp(h, len(h)-1, less)
+	return h
+}
+
+func heapPop[T any](h []T, less func(a, b T) bool) (T, []T) {
+	top := h[0]
+	return top, heapRemove(h, 0, less)
+}
+
+func heapRemove[T any](h []T, i int, less func(a, b T) bool) []T {
+	last := len(h) - 1
+	h[i] = h[last]
+	var zero T
+	h[last] = zero
+	h = h[:last]
+	if i < last {
+		heapDown(h, i, less)
+		heapUp(h, i, less)
+	}
+	return h
+}
+
+func heapUp[T any](h []T, i int, less func(a, b T) bool) {
+	for i > 0 {
+		parent := (i - 1) / 4
+		if !less(h[i], h[parent]) {
+			return
+		}
+		h[i], h[parent] = h[parent], h[i]
+		i = parent
+	}
+}
+
+func heapDown[T any](h []T, i int, less func(a, b T) bool) {
+	for {
+		smallest := i
+		for c := 4*i + 1; c <= 4*i+4 && c < len(h); c++ {
+			if less(h[c], h[smallest]) {
+				smallest = c
+			}
+		}
+		if smallest == i {
+			return
+		}
+		h[i], h[smallest] = h[smallest], h[i]
+		i = smallest
+	}
+}
+
+// WithSmallHeap 任务数少于 threshold 时使用 4 叉堆代替时间轮，默认关闭
+// 堆满时全部迁入时间轮，时间轮清空后新任务重新进入堆，
+// 适合每个连接只有少量超时的嵌入场景，以降低内存占用
+func WithSmallHeap(threshold int) Option {
+	return func(t *Timer) {
+		t.heapLimit = max(threshold, 0)
+	}
+}
+
+// dueAt 返回条目进入触发阶段的时间，关键任务提前 criticalLead
+func (t *Timer) dueAt(e *Entry) time.Time {
+	if e.critical {
+		return e.expireAt.Add(-t.criticalLead)
+	}
+	return e.expireAt
+}
+
+// addToHeap 时间轮未启用且堆未满时放入堆，返回是否已放入
+func (t *Timer) addToHeap(entry *Entry, now time.Time) bool {
+	if t.heapLimit == 0 || t.wheel != nil {
+		return false
+	}
+	if len(t.heap) < t.heapLimit {
+		t.heap.push(t, entry)
+		return true
+	}
+
+	// 堆已满，全部迁入时间轮
+	for i, e := range t.heap {
+		due := t.dueAt(e)
+		if due.Before(now) {
+			due = now
+		}
+		t.placeInWheel(e, due, now)
+		t.heap[i] = nil
+	}
+	t.heap = t.heap[:0]
+	return false
+}
+
+// handleHeapExpired 触发堆中的到期任务
+func (t *Timer) handleHeapExpired() {
+	now := t.now()
+	for len(t.heap) > 0 && !t.dueAt(t.heap[0]).After(now) {
+		t.expire(t.heap.pop(t))
+	}
+}
+
+// nextHeap 返回堆中最早的到期时间
+func (t *Timer) nextHeap() *time.Time {
+	if len(t.heap) == 0 {
+		return nil
+	}
+	next := t.dueAt(t.heap[0])
+	return &next
+}
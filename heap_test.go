@@ -0,0 +1,73 @@
+package whTimer
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTimerSmallHeap(t *testing.T) {
+	var fired []int
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithSmallHeap(4))
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	add := func(ms int) {
+		timer.AddEntry(time.Duration(ms)*time.Millisecond, func() { fired = append(fired, ms) })
+	}
+	for _, ms := range []int{300, 100, 200} {
+		add(ms)
+	}
+	timer.Advance(base)
+	if timer.wheel != nil || len(timer.heap) != 3 || timer.Pending() != 3 {
+		t.Fatalf("wheel=%v heap=%d pending=%d", timer.wheel != nil, len(timer.heap), timer.Pending())
+	}
+	if up := timer.Upcoming(1); len(up) != 1 || !up[0].Deadline.Equal(base.Add(100*time.Millisecond)) {
+		t.Fatalf("upcoming=%+v", up)
+	}
+
+	timer.Advance(base.Add(150 * time.Millisecond))
+	for _, ms := range []int{400, 250, 500} {
+		add(ms)
+	}
+	timer.Advance(base.Add(150 * time.Millisecond))
+	if timer.wheel == nil || len(timer.heap) != 0 || timer.Pending() != 5 {
+		t.Fatalf("expected promotion: heap=%d pending=%d", len(timer.heap), timer.Pending())
+	}
+
+	timer.Advance(base.Add(time.Second))
+	// 后三个任务在 150ms 时添加，按绝对到期时间 400/550/650ms 触发
+	if !slices.Equal(fired, []int{100, 200, 300, 250, 400, 500}) {
+		t.Fatalf("fired=%v", fired)
+	}
+
+	timer.Advance(base.Add(2 * time.Second))
+	add(10)
+	timer.Advance(base.Add(2 * time.Second))
+	if timer.wheel != nil || len(timer.heap) != 1 {
+		t.Fatal("new entry should return to the heap once the wheel is empty")
+	}
+}
+
+func TestTimerSmallHeapRunning(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithSmallHeap(2))
+	timer.Start()
+	defer timer.Stop()
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		timer.AddEntry(time.Duration(i%3)*5*time.Millisecond, wg.Done)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("entries did not fire")
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package whTimer
+
+// beginHighResolution 非 Windows 平台的运行时定时器已具备毫秒以下精度，无需处理
+func beginHighResolution() {}
+
+// endHighResolution 非 Windows 平台无需处理
+func endHighResolution() {}
@@ -0,0 +1,27 @@
+//go:build windows
+
+package whTimer
+
+import (
+	"syscall"
+)
+
+var (
+	winmm           = syscall.NewLazyDLL("winmm.dll")
+	timeBeginPeriod = winmm.NewProc("timeBeginPeriod")
+	timeEndPeriod   = winmm.NewProc("timeEndPeriod")
+)
+
+// beginHighResolution 请求 1ms 系统时钟分辨率（默认约 15.6ms）
+func beginHighResolution() {
+	if timeBeginPeriod.Find() == nil {
+		timeBeginPeriod.Call(1)
+	}
+}
+
+// endHighResolution 撤销 beginHighResolution 的请求
+func endHighResolution() {
+	if timeEndPeriod.Find() == nil {
+		timeEndPeriod.Call(1)
+	}
+}
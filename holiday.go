@@ -0,0 +1,121 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// Calendar 排除日历，Excluded 返回 true 的日期不触发
+type Calendar interface {
+	Excluded(t time.Time) bool
+}
+
+// CalendarFunc 函数形式的排除日历
+type CalendarFunc func(t time.Time) bool
+
+// Excluded 实现 Calendar
+func (f CalendarFunc) Excluded(t time.Time) bool {
+	return f(t)
+}
+
+// Weekends 排除周六、周日
+var Weekends = CalendarFunc(func(t time.Time) bool {
+	w := t.Weekday()
+	return w == time.Saturday || w == time.Sunday
+})
+
+// DateSet 按日期排除的日历，如法定节假日
+type DateSet struct {
+	loc   *time.Location
+	dates map[[3]int]struct{}
+}
+
+// NewDateSet 创建日期集合，日期按 loc 时区比较，loc 为 nil 时使用本地时区
+func NewDateSet(loc *time.Location, dates ...time.Time) *DateSet {
+	if loc == nil {
+		loc = time.Local
+	}
+	s := &DateSet{loc: loc, dates: make(map[[3]int]struct{}, len(dates))}
+	for _, d := range dates {
+		s.Add(d)
+	}
+	return s
+}
+
+// Add 添加排除日期，非并发安全，应在创建周期任务前完成
+func (s *DateSet) Add(d time.Time) {
+	s.dates[dateKey(d.In(s.loc))] = struct{}{}
+}
+
+// Excluded 实现 Calendar
+func (s *DateSet) Excluded(t time.Time) bool {
+	_, ok := s.dates[dateKey(t.In(s.loc))]
+	return ok
+}
+
+func dateKey(t time.Time) [3]int {
+	y, m, d := t.Date()
+	return [3]int{y, int(m), d}
+}
+
+// Calendars 组合多个日历，任一排除即排除
+func Calendars(cals ...Calendar) Calendar {
+	return CalendarFunc(func(t time.Time) bool {
+		for _, c := range cals {
+			if c.Excluded(t) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// ExclusionPolicy 发生时间落在排除日期时的处理方式
+type ExclusionPolicy int
+
+const (
+	ExcludeSkip  ExclusionPolicy = iota // 跳过本次
+	ExcludeShift                        // 顺延到下一个未被排除的日期，时刻不变
+)
+
+// exclusionMaxTries 连续被排除的最大次数，超过后视为不再触发
+const exclusionMaxTries = 10000
+
+// exclusionSchedule 带排除日历的调度规则
+type exclusionSchedule struct {
+	schedule Schedule
+	calendar Calendar
+	policy   ExclusionPolicy
+}
+
+// Exclude 为调度规则附加排除日历，可配合 AddSchedule 用于 Cron、DailyAt 等任意规则
+func Exclude(schedule Schedule, calendar Calendar, policy ExclusionPolicy) Schedule {
+	return &exclusionSchedule{schedule: schedule, calendar: calendar, policy: policy}
+}
+
+// Next 实现 Schedule
+func (s *exclusionSchedule) Next(t time.Time) time.Time {
+	next := t
+	for i := 0; i < exclusionMaxTries; i++ {
+		next = s.schedule.Next(next)
+		if next.IsZero() || !s.calendar.Excluded(next) {
+			return next
+		}
+		if s.policy == ExcludeShift {
+			return s.shift(next)
+		}
+	}
+	return time.Time{}
+}
+
+// shift 逐日顺延到未被排除的日期
+func (s *exclusionSchedule) shift(t time.Time) time.Time {
+	year, month, day := t.Date()
+	hour, minute, sec := t.Clock()
+	for i := 1; i <= exclusionMaxTries; i++ {
+		next := time.Date(year, month, day+i, hour, minute, sec, t.Nanosecond(), t.Location())
+		if !s.calendar.Excluded(next) {
+			return next
+		}
+	}
+	return time.Time{}
+}
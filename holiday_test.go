@@ -0,0 +1,69 @@
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExclude(t *testing.T) {
+	date := func(m time.Month, d int) time.Time {
+		return time.Date(2025, m, d, 9, 30, 0, 0, time.UTC)
+	}
+	daily := &clockSchedule{hour: 9, min: 30, loc: time.UTC}
+	holidays := NewDateSet(time.UTC, date(1, 1)) // 周三
+	cal := Calendars(holidays, Weekends)
+
+	skip := Exclude(daily, cal, ExcludeSkip)
+	got := collect(skip, date(12, 31).AddDate(-1, 0, 0), 3)
+	want := []time.Time{date(1, 2), date(1, 3), date(1, 6)}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Fatalf("skip: got %v, want %v", got, want)
+		}
+	}
+
+	// 周五的周任务遇到节假日顺延到下一个工作日
+	weekly := &clockSchedule{hour: 9, min: 30, weekly: true, weekday: time.Friday, loc: time.UTC}
+	shift := Exclude(weekly, Calendars(NewDateSet(time.UTC, date(1, 10)), Weekends), ExcludeShift)
+	if next := shift.Next(date(1, 4)); !next.Equal(date(1, 13)) {
+		t.Fatalf("shift: %v", next)
+	}
+	if next := shift.Next(date(1, 13)); !next.Equal(date(1, 17)) {
+		t.Fatalf("after shift: %v", next)
+	}
+
+	for _, next := range collect(Exclude(daily, Weekends, ExcludeSkip), date(1, 1), 10) {
+		if Weekends(next) {
+			t.Fatalf("cron exclude fired on %v", next)
+		}
+	}
+}
+
+func TestWithin(t *testing.T) {
+	at := func(d, h, m int) time.Time {
+		return time.Date(2025, 3, d, h, m, 0, 0, time.UTC) // 3 月 10 日为周一
+	}
+	hours, err := NewBusinessHours("08:00", "20:00", time.UTC,
+		time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, _ := ParseRRule("FREQ=HOURLY;INTERVAL=5", at(10, 0, 30))
+	w := Within(s, hours)
+	cases := []struct{ from, want time.Time }{
+		{at(10, 0, 0), at(10, 8, 0)},   // 05:30 推迟到 08:00
+		{at(10, 8, 0), at(10, 10, 30)}, // 窗口内原样
+		{at(10, 15, 30), at(11, 8, 0)}, // 20:30、01:30、06:30 合并到次日 08:00
+		{at(14, 19, 45), at(17, 8, 0)}, // 周五晚推迟到周一
+	}
+	for _, c := range cases {
+		if got := w.Next(c.from); !got.Equal(c.want) {
+			t.Errorf("Next(%v)=%v, want %v", c.from, got, c.want)
+		}
+	}
+
+	if _, err := NewBusinessHours("20:00", "08:00", nil); err == nil {
+		t.Error("inverted hours accepted")
+	}
+}
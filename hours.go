@@ -0,0 +1,100 @@
+package whTimer
+
+import (
+	"fmt"
+	"time"
+)
+
+// BusinessHours 每周营业时间窗口，如周一至周五 08:00-20:00
+type BusinessHours struct {
+	start, end time.Duration // 距当天零点的偏移
+	weekdays   uint8         // 按 time.Weekday 置位
+	loc        *time.Location
+}
+
+// NewBusinessHours 创建营业时间窗口，start/end 格式为 "08:00" 或 "08:00:00"
+// days 为空时表示每天，loc 为 nil 时使用本地时区
+func NewBusinessHours(start, end string, loc *time.Location, days ...time.Weekday) (*BusinessHours, error) {
+	sh, sm, ss, err := parseClock(start)
+	if err != nil {
+		return nil, err
+	}
+	eh, em, es, err := parseClock(end)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &BusinessHours{
+		start: time.Duration(sh)*time.Hour + time.Duration(sm)*time.Minute + time.Duration(ss)*time.Second,
+		end:   time.Duration(eh)*time.Hour + time.Duration(em)*time.Minute + time.Duration(es)*time.Second,
+		loc:   loc,
+	}
+	if b.start >= b.end {
+		return nil, fmt.Errorf("whTimer: invalid business hours %s-%s: start must be before end", start, end)
+	}
+	if b.loc == nil {
+		b.loc = time.Local
+	}
+	for _, d := range days {
+		if d < time.Sunday || d > time.Saturday {
+			return nil, fmt.Errorf("whTimer: invalid weekday %d", d)
+		}
+		b.weekdays |= 1 << d
+	}
+	if b.weekdays == 0 {
+		b.weekdays = 1<<7 - 1
+	}
+	return b, nil
+}
+
+// bounds 返回 t 所在日期第 i 天后的窗口
+func (b *BusinessHours) bounds(t time.Time, i int) (start, end time.Time, ok bool) {
+	year, month, day := t.In(b.loc).Date()
+	midnight := time.Date(year, month, day+i, 0, 0, 0, 0, b.loc)
+	if b.weekdays&(1<<midnight.Weekday()) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	return midnight.Add(b.start), midnight.Add(b.end), true
+}
+
+// Contains 检查 t 是否在窗口内
+func (b *BusinessHours) Contains(t time.Time) bool {
+	start, end, ok := b.bounds(t, 0)
+	return ok && !t.Before(start) && t.Before(end)
+}
+
+// NextOpen 返回不早于 t 的最近窗口内时刻，t 在窗口内时返回 t 本身
+func (b *BusinessHours) NextOpen(t time.Time) time.Time {
+	for i := 0; i <= 7; i++ {
+		start, end, ok := b.bounds(t, i)
+		if !ok || !t.Before(end) {
+			continue
+		}
+		if t.Before(start) {
+			return start
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// businessSchedule 限制在营业时间内触发的调度规则
+type businessSchedule struct {
+	schedule Schedule
+	hours    *BusinessHours
+}
+
+// Within 将调度规则限制在营业时间内，窗口外的发生推迟到下一个窗口起点，
+// 同一段窗口外时间内的多次发生合并为一次
+func Within(schedule Schedule, hours *BusinessHours) Schedule {
+	return &businessSchedule{schedule: schedule, hours: hours}
+}
+
+// Next 实现 Schedule
+func (s *businessSchedule) Next(t time.Time) time.Time {
+	next := s.schedule.Next(t)
+	if next.IsZero() || s.hours.Contains(next) {
+		return next
+	}
+	return s.hours.NextOpen(next)
+}
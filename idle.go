@@ -0,0 +1,90 @@
+package whTimer
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// idle 状态字的特殊取值，非负时为最近一次活动的时间（UnixNano）
+const (
+	idleFired   = -1
+	idleStopped = -2
+)
+
+// IdleTimer 空闲检测器，超过 timeout 未 Touch 即触发回调
+// 活动时间与触发状态保存在检测器自身，条目触发后被回收复用也不影响 Touch、Stop 与 Fired
+type IdleTimer struct {
+	timer   *Timer
+	timeout time.Duration
+	onIdle  func()
+	last    atomic.Int64 // 最近一次活动时间，或 idleFired、idleStopped
+	entry   atomic.Pointer[EntryRef]
+}
+
+// Idle 创建空闲检测器，超过 timeout 未调用 Touch 时执行 onIdle
+// 示例: 连接每次收到数据时调用 Touch，空闲超时后关闭连接
+func (t *Timer) Idle(timeout time.Duration, onIdle func()) *IdleTimer {
+	i := &IdleTimer{timer: t, timeout: timeout, onIdle: onIdle}
+	now := t.now()
+	i.last.Store(now.UnixNano())
+	i.arm(now.Add(timeout))
+	return i
+}
+
+// arm 在 at 处添加检查条目并记录其引用
+func (i *IdleTimer) arm(at time.Time) {
+	ref := i.timer.addRef(at, i.check, nil)
+	i.entry.Store(&ref)
+}
+
+// check 条目到期时检查空闲时长，期间有过 Touch 时按最近一次活动重新计时
+func (i *IdleTimer) check() {
+	for {
+		last := i.last.Load()
+		if last < 0 {
+			return
+		}
+		if deadline := time.Unix(0, last).Add(i.timeout); i.timer.now().Before(deadline) {
+			i.arm(deadline)
+			return
+		}
+		if i.last.CompareAndSwap(last, idleFired) {
+			i.onIdle()
+			return
+		}
+	}
+}
+
+// Touch 标记一次活动，重新开始计时 - O(1)
+// 只记录活动时间，原条目到期时再按其重新计时；已触发或已停止时返回 false
+func (i *IdleTimer) Touch() bool {
+	now := i.timer.now().UnixNano()
+	for {
+		last := i.last.Load()
+		if last < 0 {
+			return false
+		}
+		if i.last.CompareAndSwap(last, max(last, now)) {
+			return true
+		}
+	}
+}
+
+// Stop 停止检测，返回是否在触发前成功停止
+func (i *IdleTimer) Stop() bool {
+	for {
+		last := i.last.Load()
+		if last < 0 {
+			return false
+		}
+		if i.last.CompareAndSwap(last, idleStopped) {
+			i.entry.Load().Cancel()
+			return true
+		}
+	}
+}
+
+// Fired 检查是否已因空闲而触发
+func (i *IdleTimer) Fired() bool {
+	return i.last.Load() == idleFired
+}
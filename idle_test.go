@@ -0,0 +1,58 @@
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdleTimer(t *testing.T) {
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	})
+	timer.Start()
+	defer timer.Stop()
+
+	// 无活动：超时后触发，之后 Touch 与 Stop 均失败
+	fired := make(chan struct{}, 1)
+	idle := timer.Idle(10*time.Millisecond, func() { fired <- struct{}{} })
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("idle timer did not fire")
+	}
+	if !idle.Fired() {
+		t.Fatal("Fired() = false after the idle timer fired")
+	}
+	// 再添加的任务会复用已回收的条目，不影响检测器的状态
+	timer.AddEntry(time.Hour, func() {})
+	if !idle.Fired() || idle.Touch() || idle.Stop() {
+		t.Fatal("Touch or Stop succeeded after the idle timer fired")
+	}
+
+	// 持续 Touch 时不触发，停止活动后按最近一次活动计时触发
+	idle = timer.Idle(30*time.Millisecond, func() { fired <- struct{}{} })
+	for range 10 {
+		time.Sleep(10 * time.Millisecond)
+		if !idle.Touch() {
+			t.Fatal("Touch failed before the idle timer fired")
+		}
+	}
+	if idle.Fired() {
+		t.Fatal("idle timer fired while being touched")
+	}
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("touched idle timer did not fire after activity stopped")
+	}
+
+	// Stop 后不再触发
+	idle = timer.Idle(10*time.Millisecond, func() { fired <- struct{}{} })
+	if !idle.Stop() || idle.Stop() {
+		t.Fatal("Stop should succeed exactly once")
+	}
+	time.Sleep(30 * time.Millisecond)
+	if len(fired) != 0 || idle.Fired() || idle.Touch() {
+		t.Fatal("stopped idle timer fired or accepted Touch")
+	}
+}
@@ -0,0 +1,43 @@
+package whTimer
+
+import (
+	"context"
+	"time"
+)
+
+// AddEntryCtx 添加附带上下文的定时任务 - Wait-Free
+// 上下文仅随条目保存并由 AddChild 派生的任务继承，取消上下文不会取消任务
+func (t *Timer) AddEntryCtx(ctx context.Context, delay time.Duration, callback func()) *Entry {
+	entry := t.newEntry(t.now().Add(delay), callback)
+	entry.delay = delay
+	entry.ctx = ctx
+	return t.push(entry)
+}
+
+// AddEntryFn 添加回调接收自身条目的定时任务 - Wait-Free
+// 回调中可通过 e.AddChild 派生继承标签、优先级与上下文的后续任务
+func (t *Timer) AddEntryFn(delay time.Duration, fn func(e *Entry)) *Entry {
+	entry := t.newEntry(t.now().Add(delay), nil)
+	entry.callback = func() { fn(entry) }
+	entry.delay = delay
+	return t.push(entry)
+}
+
+// AddChild 在条目所属定时器上派生后续任务 - Wait-Free
+// 新任务继承该条目的标签、关键任务标记、专属 handler（如 SubTimer）与上下文，
+// 使回调中级联调度的任务仍可归属与按原优先级处理；应在条目执行期间（回调或 handler 中）调用
+func (e *Entry) AddChild(delay time.Duration, callback func()) *Entry {
+	return e.AddChildAt(e.owner.now().Add(delay), callback)
+}
+
+// AddChildAt 在指定时间派生后续任务，继承规则同 AddChild - Wait-Free
+func (e *Entry) AddChildAt(expireAt time.Time, callback func()) *Entry {
+	t := e.owner
+	child := t.newEntry(expireAt, callback)
+	child.delay = expireAt.Sub(t.now())
+	child.tag = e.tag
+	child.critical = e.critical
+	child.handler = e.handler
+	child.ctx = e.ctx
+	return t.push(child)
+}
@@ -0,0 +1,48 @@
+package whTimer
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestEntryAddChild(t *testing.T) {
+	type traceKey struct{}
+	type fired struct {
+		tag   string
+		trace any
+	}
+	var got []fired
+	timer := NewTimer(func(e *Entry) {
+		got = append(got, fired{e.Tag(), e.Context().Value(traceKey{})})
+		e.Execute()
+	})
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	ctx := context.WithValue(context.Background(), traceKey{}, "trace-1")
+	parent := timer.AddEntryCtx(ctx, 10*time.Millisecond, nil)
+	parent.tag = "job"
+	parent.callback = func() {
+		parent.AddChild(10*time.Millisecond, func() {})
+	}
+	timer.Advance(base.Add(20 * time.Millisecond))
+	timer.Advance(base.Add(30 * time.Millisecond))
+
+	if len(got) != 2 {
+		t.Fatalf("fired %d entries", len(got))
+	}
+	if got[1] != (fired{"job", "trace-1"}) {
+		t.Fatalf("child=%+v", got[1])
+	}
+
+	var inherited bool
+	timer.AddEntryFn(10*time.Millisecond, func(e *Entry) {
+		e.AddChild(5*time.Millisecond, func() { inherited = true })
+	})
+	timer.Advance(base.Add(40 * time.Millisecond))
+	timer.Advance(base.Add(50 * time.Millisecond))
+	if !inherited || got[len(got)-1] != (fired{}) {
+		t.Fatal("AddEntryFn child did not run")
+	}
+}
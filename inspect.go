@@ -0,0 +1,250 @@
+package whTimer
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"time"
+)
+
+// EntryInfo 定时任务快照信息
+type EntryInfo struct {
+	ID       uint64
+	Tag      string
+	Deadline time.Time
+}
+
+// Snapshot 定时器内部状态快照，由 run loop 复制生成，读取时不会与时间轮产生数据竞争
+type Snapshot struct {
+	Start   time.Time   // 时间轮的起点
+	Level   int         // 时间轮最高层级，未建立时为 -1
+	Pending uint64      // 时间轮、小堆与限流队列中的任务数
+	Fired   uint64      // 已交给 handler 的任务数
+	Entries []EntryInfo // 所有未取消的待触发任务，按截止时间排序
+}
+
+// Dump 返回定时器内部状态快照，包含全部待触发任务，开销与任务数成正比
+func (t *Timer) Dump() Snapshot {
+	s := Snapshot{Level: -1}
+	t.inspect(func() {
+		t.walkPending(func(e *Entry) {
+			if !e.IsCanceled() {
+				s.Entries = append(s.Entries, EntryInfo{ID: e.id, Tag: e.tag, Deadline: e.deadline()})
+			}
+		})
+		if t.wheel != nil {
+			s.Start = t.start
+			s.Level = t.wheel.Level()
+		}
+		s.Pending = t.pending()
+		s.Fired = t.fired
+	})
+	slices.SortStableFunc(s.Entries, func(a, b EntryInfo) int {
+		return a.Deadline.Compare(b.Deadline)
+	})
+	return s
+}
+
+// inspect 在 run loop 中同步执行 fn，避免与时间轮产生数据竞争
+// 定时器未运行时直接在调用方执行；run loop 正在调用 handler 或钩子时时间轮状态不变，
+// 由 inspectInline 在调用方执行，handler 内调用以及 handler 等待的其他 goroutine 调用都不会死锁
+func (t *Timer) inspect(fn func()) {
+	if t.inspectInline(fn) {
+		return
+	}
+	if t.running.Load() {
+		if t.onDemand {
+			t.inspectWanted.Add(1)
+			defer t.inspectWanted.Add(-1)
+			t.ensureLoop()
+		}
+
+		done := make(chan struct{})
+		select {
+		case t.inspectChan <- func() {
+			fn()
+			t.publishPending()
+			close(done)
+		}:
+			<-done
+			return
+		case <-t.doneChan:
+		}
+	}
+	fn()
+	t.publishPending()
+}
+
+// inspectInline run loop 正在执行用户代码时在调用方执行 fn，返回是否已执行
+// 此时外层仍在遍历时间轮与队列，fn 不排空队列，也不调整时间轮结构，见 idle
+func (t *Timer) inspectInline(fn func()) bool {
+	if !t.inCallback.Load() {
+		return false
+	}
+	t.inlineMu.Lock()
+	defer t.inlineMu.Unlock()
+	t.inlineN.Add(1)
+	defer t.inlineN.Add(-1)
+	// 计数先于检查：run loop 清除标志后等待计数归零，两者至少有一方看到对方的写入
+	if !t.inCallback.Load() {
+		return false
+	}
+	t.reentered = true
+	fn()
+	t.reentered = false
+	return true
+}
+
+// enterCallback run loop 调用 handler 与钩子前标记，期间 inspect 在调用方执行
+func (t *Timer) enterCallback() {
+	t.inCallback.Store(true)
+}
+
+// exitCallback 用户代码返回后清除标记，并等待正在调用方执行的 inspect 结束
+func (t *Timer) exitCallback() {
+	t.inCallback.Store(false)
+	for t.inlineN.Load() != 0 {
+		runtime.Gosched()
+	}
+}
+
+// idle 检查是否可以调整时间轮结构：不在 handler 对 inspect 的重入中
+// Compact、Reroot、迁移等在 handler 中调用时不执行
+func (t *Timer) idle() bool {
+	return !t.reentered
+}
+
+// walkPending 遍历所有尚未交给 handler 的条目（含队列中尚未放入时间轮的条目），仅在 run loop 中调用
+// 只读遍历，不排空队列，也不触发已到期的条目
+func (t *Timer) walkPending(fn func(*Entry)) {
+	t.queue.peek(fn)
+	if t.wheel != nil {
+		t.wheel.Walk(func(e *Entry, _ uint64) bool {
+			fn(e)
+			return true
+		})
+	}
+	for _, e := range t.heap {
+		fn(e)
+	}
+	for _, e := range t.precise {
+		fn(e)
+	}
+	for _, e := range t.rearm {
+		fn(e)
+	}
+	for _, e := range t.order.expired {
+		fn(e)
+	}
+	for _, e := range t.rate.deferred[t.rate.head:] {
+		fn(e)
+	}
+	for _, g := range t.groups {
+		for _, e := range g.queue[g.head:] {
+			fn(e)
+		}
+	}
+}
+
+// Upcoming 返回按触发顺序排列的前 n 个待执行任务快照
+func (t *Timer) Upcoming(n int) []EntryInfo {
+	if n <= 0 {
+		return nil
+	}
+
+	var result []EntryInfo
+	t.inspect(func() {
+		t.queue.peek(func(e *Entry) {
+			if !e.IsCanceled() {
+				result = append(result, EntryInfo{ID: e.id, Tag: e.tag, Deadline: e.deadline()})
+			}
+		})
+		for _, e := range t.precise {
+			if !e.IsCanceled() {
+				result = append(result, EntryInfo{ID: e.id, Tag: e.tag, Deadline: e.deadline()})
+			}
+		}
+		for _, e := range t.heap {
+			if !e.IsCanceled() {
+				result = append(result, EntryInfo{ID: e.id, Tag: e.tag, Deadline: e.deadline()})
+			}
+		}
+		if t.wheel == nil {
+			return
+		}
+
+		lastMs := ^uint64(0)
+		t.wheel.Walk(func(e *Entry, ms uint64) bool {
+			// 同一毫秒槽内的任务需全部收集后再排序；被 Refresh 或 Snooze 推迟的任务仍在原槽位，
+			// 已收集的任务中至少 n 个不晚于当前槽位时才能结束遍历
+			if len(result) >= n && ms != lastMs && countDue(result, e.expireAt) >= n {
+				return false
+			}
+			if !e.IsCanceled() {
+				result = append(result, EntryInfo{ID: e.id, Tag: e.tag, Deadline: e.deadline()})
+				lastMs = ms
+			}
+			return true
+		})
+	})
+
+	slices.SortStableFunc(result, func(a, b EntryInfo) int {
+		return a.Deadline.Compare(b.Deadline)
+	})
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// countDue 返回截止时间不晚于 at 的任务数
+func countDue(entries []EntryInfo, at time.Time) int {
+	n := 0
+	for _, e := range entries {
+		if !e.Deadline.After(at) {
+			n++
+		}
+	}
+	return n
+}
+
+// Validate 在 run loop 中检查时间轮结构与任务计数，返回第一个违反项的描述
+// 在 handler 中调用时计数正在更新，不做检查
+func (t *Timer) Validate() error {
+	var err error
+	t.inspect(func() {
+		if t.wheel == nil || !t.idle() {
+			return
+		}
+		if err = t.wheel.Validate(); err != nil {
+			return
+		}
+		var n uint64
+		t.wheel.Walk(func(*Entry, uint64) bool {
+			n++
+			return true
+		})
+		if n != t.numEntries {
+			err = fmt.Errorf("whTimer: wheel holds %d entries but numEntries is %d", n, t.numEntries)
+		}
+	})
+	return err
+}
+
+// Resolution 返回时间轮的精度，即最底层槽位的时长
+// 短于该值的延迟会向上取整，调用方可据此决定是否改用标准库定时器
+func (t *Timer) Resolution() time.Duration {
+	return time.Duration(msPerSlot[0]) * time.Millisecond
+}
+
+// CurrentLevel 返回当前时间轮的最高层级，未建立时间轮（无任务或任务在小堆中）时返回 -1
+// 层级 n 可覆盖的最大延迟为 64^(n+1) 毫秒
+func (t *Timer) CurrentLevel() int {
+	level := -1
+	t.inspect(func() {
+		if t.wheel != nil {
+			level = t.wheel.Level()
+		}
+	})
+	return level
+}
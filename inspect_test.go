@@ -0,0 +1,234 @@
+package whTimer
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTimerUpcoming(t *testing.T) {
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	})
+	timer.Start()
+	defer timer.Stop()
+
+	timer.AddTaggedEntry("c", 300*time.Millisecond, func() {})
+	timer.AddTaggedEntry("a", 100*time.Millisecond, func() {})
+	timer.AddTaggedEntry("b", 200*time.Millisecond, func() {})
+	timer.AddTaggedEntry("x", 150*time.Millisecond, func() {}).Cancel()
+
+	infos := timer.Upcoming(2)
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(infos))
+	}
+	if infos[0].Tag != "a" || infos[1].Tag != "b" {
+		t.Errorf("expected tags [a b], got [%s %s]", infos[0].Tag, infos[1].Tag)
+	}
+}
+
+func TestTimerResolutionLevel(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	if timer.Resolution() != time.Millisecond {
+		t.Fatalf("resolution=%v", timer.Resolution())
+	}
+	if level := timer.CurrentLevel(); level != -1 {
+		t.Fatalf("empty timer level=%d", level)
+	}
+	timer.AddEntry(10*time.Millisecond, func() {})
+	timer.Advance(base)
+	if level := timer.CurrentLevel(); level != 0 {
+		t.Fatalf("level=%d, want 0", level)
+	}
+	timer.AddEntry(time.Hour, func() {})
+	timer.Advance(base)
+	if level := timer.CurrentLevel(); level != 3 {
+		t.Fatalf("level=%d, want 3", level)
+	}
+}
+
+func TestWheelValidate(t *testing.T) {
+	w := NewWheel(2)
+	var entries []*Entry
+	for _, ms := range []uint64{1, 5, 5, 70, 4000, 100000} {
+		e := NewEntry(time.Time{}, nil)
+		entries = append(entries, e)
+		for ms >= w.MaxMs() {
+			w = w.LevelUp()
+		}
+		w.AddEntry(e, ms)
+	}
+	if err := w.Validate(); err != nil {
+		t.Fatalf("valid wheel: %v", err)
+	}
+
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Advance(time.Unix(1000, 0))
+	for _, d := range []time.Duration{time.Millisecond, time.Second, time.Hour} {
+		timer.AddEntry(d, func() {})
+	}
+	timer.Advance(time.Unix(1000, 0))
+	if err := timer.Validate(); err != nil {
+		t.Fatalf("timer: %v", err)
+	}
+	timer.numEntries++
+	if err := timer.Validate(); err == nil {
+		t.Fatal("numEntries mismatch not detected")
+	}
+
+	leaf := w.subWheels[0].subWheels[0]
+	for leaf.level > 0 {
+		leaf = leaf.subWheels[0]
+	}
+	leaf.bitmap |= 1 << 9
+	if err := w.Validate(); err == nil || !strings.Contains(err.Error(), "slot 9") {
+		t.Fatalf("bitmap corruption not detected: %v", err)
+	}
+	leaf.bitmap &^= 1 << 9
+
+	// 同一槽内后加入的条目在链表头部，让尾部指回头部形成环
+	setNext(entries[1], entries[2])
+	if err := w.Validate(); err == nil || !strings.Contains(err.Error(), "more than once") {
+		t.Fatalf("cycle not detected: %v", err)
+	}
+}
+
+func TestTimerDumpConcurrent(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// 分批添加：Dump 只读遍历队列、不代为排空，生产者不能无限快于 run loop
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				for range 100 {
+					timer.AddEntry(time.Duration(rand.IntN(5))*time.Millisecond, func() {})
+				}
+				time.Sleep(100 * time.Microsecond)
+			}
+		}
+	}()
+
+	// 在 -race 下运行时，读取与 run loop 并发也不会产生数据竞争
+	for range 100 {
+		s := timer.Dump()
+		if !slices.IsSortedFunc(s.Entries, func(a, b EntryInfo) int {
+			return a.Deadline.Compare(b.Deadline)
+		}) {
+			t.Fatal("snapshot entries not sorted by deadline")
+		}
+		timer.Pending()
+		timer.Stats()
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestTimerInspectFromHandler(t *testing.T) {
+	var timer *Timer
+	results := make(chan [2]int, 1)
+	timer = NewTimer(func(e *Entry) {
+		// handler 运行在 run loop 中，同步查询须直接执行而非等待 run loop
+		pending := timer.Pending()
+		timer.Stats()
+		timer.Dump()
+		timer.Upcoming(4)
+		timer.CancelWhere(func(*Entry) bool { return false })
+		timer.Leaks()
+		timer.Spilled()
+		timer.DelayStats()
+		timer.ClockDrift()
+		compacted := timer.Compact()
+		e.Execute()
+		results <- [2]int{int(pending), compacted}
+	})
+	timer.Start()
+	defer timer.Stop()
+
+	timer.AddEntry(time.Millisecond, func() {})
+	timer.AddEntry(time.Hour, func() {})
+	select {
+	case got := <-results:
+		// 一小时后的任务仍在时间轮中；handler 中不调整时间轮结构
+		if got[0] < 1 || got[1] != 0 {
+			t.Fatalf("pending=%d compacted=%d", got[0], got[1])
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("inspect from handler deadlocked")
+	}
+	if n := timer.Pending(); n != 1 {
+		t.Fatalf("pending=%d", n)
+	}
+}
+
+func TestTimerInspectFromWaitedGoroutine(t *testing.T) {
+	var timer *Timer
+	done := make(chan struct{})
+	timer = NewTimer(func(e *Entry) {
+		// handler 同步等待的 goroutine 中调用查询，run loop 正在 handler 中，查询在调用方执行
+		queried := make(chan struct{})
+		go func() {
+			timer.Pending()
+			timer.Stats()
+			timer.Dump()
+			timer.Upcoming(4)
+			close(queried)
+		}()
+		<-queried
+		e.Execute()
+	})
+	timer.Start()
+	defer timer.Stop()
+
+	timer.AddEntry(time.Millisecond, func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("query from a goroutine the handler waits on deadlocked")
+	}
+}
+
+func TestTimerInspectReadOnly(t *testing.T) {
+	var fired int
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+	timer.AddEntry(0, func() { fired++ })
+	timer.AddEntry(time.Second, func() { fired++ })
+
+	// 查询只读取队列，不放入时间轮，已到期的任务不会在查询中触发
+	if up := timer.Upcoming(4); len(up) != 2 {
+		t.Fatalf("upcoming=%+v, want both queued entries", up)
+	}
+	if s := timer.Dump(); len(s.Entries) != 2 || s.Level != -1 {
+		t.Fatalf("dump=%+v", s)
+	}
+	if err := timer.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if level := timer.CurrentLevel(); level != -1 {
+		t.Fatalf("level=%d", level)
+	}
+	if fired != 0 || timer.Pending() != 0 {
+		t.Fatalf("query had side effects: fired=%d pending=%d", fired, timer.Pending())
+	}
+
+	timer.Advance(base)
+	if fired != 1 || timer.Pending() != 1 {
+		t.Fatalf("fired=%d pending=%d", fired, timer.Pending())
+	}
+}
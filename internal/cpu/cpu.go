@@ -0,0 +1,7 @@
+//go:build !whtimer_tiny && !tinygo
+
+// Package cpu 提供 whTimer 各包共用的硬件相关常量
+package cpu
+
+// CacheLineSize 缓存行大小，用于隔离不同写入方的字段
+const CacheLineSize = 64
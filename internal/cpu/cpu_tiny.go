@@ -0,0 +1,7 @@
+//go:build whtimer_tiny || tinygo
+
+// Package cpu 提供 whTimer 各包共用的硬件相关常量
+package cpu
+
+// CacheLineSize 精简构建不做缓存行填充，只保留最小间隔
+const CacheLineSize = 8
@@ -0,0 +1,157 @@
+//go:build !whtimer_tiny && !tinygo
+
+package whTimer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// isoDurationRe ISO 8601 时长: PnYnMnDTnHnMnS 或 PnW
+var isoDurationRe = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// isoPeriod 重复周期，年月日按日历计算，其余为固定时长
+type isoPeriod struct {
+	years, months, days int
+	fixed               time.Duration
+}
+
+// minLength 周期的最短可能长度，用于估算已经过的周期数而不越过任何一次发生
+func (p isoPeriod) minLength() time.Duration {
+	return time.Duration(p.years)*365*24*time.Hour +
+		time.Duration(p.months)*28*24*time.Hour +
+		time.Duration(p.days)*23*time.Hour +
+		p.fixed
+}
+
+func (p isoPeriod) calendar() bool {
+	return p.years != 0 || p.months != 0 || p.days != 0
+}
+
+// at 返回 start 之后第 k 个周期的时间
+// 与 time.AddDate 不同，目标月份没有该日期时取月末（1 月 31 日加一个月为 2 月 28 日）
+func (p isoPeriod) at(start time.Time, k int) time.Time {
+	year, month, day := start.Date()
+	hour, minute, sec := start.Clock()
+	first := time.Date(year+k*p.years, month+time.Month(k*p.months), 1, 0, 0, 0, 0, start.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	t := time.Date(first.Year(), first.Month(), min(day, last)+k*p.days, hour, minute, sec, start.Nanosecond(), start.Location())
+	return t.Add(time.Duration(k) * p.fixed)
+}
+
+func parseISODuration(s string) (isoPeriod, bool) {
+	m := isoDurationRe.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return isoPeriod{}, false
+	}
+
+	num := func(i int) int {
+		n, _ := strconv.Atoi(m[i])
+		return n
+	}
+	p := isoPeriod{
+		years:  num(1),
+		months: num(2),
+		days:   num(3)*7 + num(4),
+		fixed:  time.Duration(num(5))*time.Hour + time.Duration(num(6))*time.Minute,
+	}
+	if m[7] != "" {
+		sec, err := strconv.ParseFloat(strings.Replace(m[7], ",", ".", 1), 64)
+		if err != nil {
+			return isoPeriod{}, false
+		}
+		p.fixed += time.Duration(sec * float64(time.Second))
+	}
+	if p.minLength() <= 0 {
+		return isoPeriod{}, false
+	}
+	return p, true
+}
+
+// isoSchedule ISO 8601 重复时间间隔
+type isoSchedule struct {
+	start  time.Time
+	period isoPeriod
+	count  int // 发生次数，0 表示不限
+}
+
+// ParseISO8601Repeating 解析 ISO 8601 重复时间间隔，如 "R5/2025-01-01T00:00:00Z/PT10S"
+// 支持 R[n]/开始/时长、R[n]/开始/结束（周期为两者之差）与 Rn/时长/结束，
+// Rn 表示共发生 n 次，省略 n 表示不限次数
+func ParseISO8601Repeating(s string) (Schedule, error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	if len(parts) != 3 || !strings.HasPrefix(parts[0], "R") {
+		return nil, fmt.Errorf("whTimer: invalid ISO 8601 repeating interval %q", s)
+	}
+
+	sched := &isoSchedule{}
+	if n := parts[0][1:]; n != "" {
+		count, err := strconv.Atoi(n)
+		if err != nil || count <= 0 {
+			return nil, fmt.Errorf("whTimer: invalid ISO 8601 repetition count %q", parts[0])
+		}
+		sched.count = count
+	}
+
+	period, isDuration := parseISODuration(parts[1])
+	switch {
+	case isDuration:
+		// Rn/时长/结束：从结束时间倒推开始时间
+		if sched.count == 0 {
+			return nil, fmt.Errorf("whTimer: ISO 8601 interval %q needs a repetition count", s)
+		}
+		end, err := time.Parse(time.RFC3339, parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("whTimer: invalid ISO 8601 end %q: %v", parts[2], err)
+		}
+		sched.start = period.at(end, -sched.count)
+		sched.period = period
+	default:
+		start, err := time.Parse(time.RFC3339, parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("whTimer: invalid ISO 8601 start %q: %v", parts[1], err)
+		}
+		sched.start = start
+		if p, ok := parseISODuration(parts[2]); ok {
+			sched.period = p
+			break
+		}
+		end, err := time.Parse(time.RFC3339, parts[2])
+		if err != nil || !end.After(start) {
+			return nil, fmt.Errorf("whTimer: invalid ISO 8601 duration or end %q", parts[2])
+		}
+		sched.period = isoPeriod{fixed: end.Sub(start)}
+	}
+	return sched, nil
+}
+
+// Repeating 按 ISO 8601 重复时间间隔创建周期任务
+func (t *Timer) Repeating(interval string, callback func()) (*CronEntry, error) {
+	s, err := ParseISO8601Repeating(interval)
+	if err != nil {
+		return nil, err
+	}
+	return t.AddSchedule(s, callback), nil
+}
+
+// Next 返回 t 之后的下一次发生时间，次数用尽后返回零值
+func (s *isoSchedule) Next(t time.Time) time.Time {
+	k := 0
+	if t.After(s.start) {
+		k = int(t.Sub(s.start) / s.period.minLength())
+		if s.period.calendar() {
+			// 按最短长度估算只会偏小，向前逐个推进即可
+			k = max(k-1, 0)
+		}
+	}
+
+	for ; s.count == 0 || k < s.count; k++ {
+		if next := s.period.at(s.start, k); next.After(t) {
+			return next
+		}
+	}
+	return time.Time{}
+}
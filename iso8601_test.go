@@ -0,0 +1,44 @@
+//go:build !whtimer_tiny && !tinygo
+
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestISO8601Repeating(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s, err := ParseISO8601Repeating("R5/2025-01-01T00:00:00Z/PT10S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := collect(s, start.Add(-time.Second), 10)
+	if len(got) != 5 || !got[0].Equal(start) || !got[4].Equal(start.Add(40*time.Second)) {
+		t.Fatalf("R5/PT10S: %v", got)
+	}
+
+	s, _ = ParseISO8601Repeating("R/2025-01-31T00:00:00Z/P1M")
+	if next := s.Next(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)); !next.Equal(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("P1M skip: %v", next)
+	}
+
+	s, _ = ParseISO8601Repeating("R/2025-01-01T00:00:00Z/2025-01-01T00:30:00Z")
+	if next := s.Next(start.Add(time.Hour)); !next.Equal(start.Add(90 * time.Minute)) {
+		t.Fatalf("start/end: %v", next)
+	}
+
+	s, _ = ParseISO8601Repeating("R2/PT1H/2025-01-01T00:00:00Z")
+	got = collect(s, start.Add(-3*time.Hour), 10)
+	if len(got) != 2 || !got[0].Equal(start.Add(-2*time.Hour)) || !got[1].Equal(start.Add(-time.Hour)) {
+		t.Fatalf("duration/end: %v", got)
+	}
+
+	for _, v := range []string{"", "R5", "X/2025-01-01T00:00:00Z/PT10S", "R0/2025-01-01T00:00:00Z/PT10S",
+		"R/2025-01-01T00:00:00Z/P", "R/2025-01-01T00:00:00Z/PT", "R/PT1H/2025-01-01T00:00:00Z", "R/bad/PT1S"} {
+		if _, err := ParseISO8601Repeating(v); err == nil {
+			t.Errorf("ParseISO8601Repeating(%q) accepted", v)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package whTimer
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Leak 疑似泄漏的定时任务：创建已久、既未触发也未取消
+type Leak struct {
+	ID       uint64
+	Tag      string
+	Created  time.Time
+	Deadline time.Time
+	Stack    string // 创建时的调用栈
+}
+
+// leakDetector 按采样率记录条目的创建时间与调用栈
+type leakDetector struct {
+	minAge  time.Duration
+	every   uint64
+	report  func([]Leak)
+	counter atomic.Uint64
+	samples sync.Map // id -> *leakSample
+}
+
+type leakSample struct {
+	ref     EntryRef
+	created time.Time
+	stack   []uintptr
+}
+
+// WithLeakDetection 开启泄漏检测（调试用），默认关闭
+// 每 sampleEvery 个新条目记录一次创建调用栈，Stop 时或调用 Leaks 时报告创建超过 minAge、
+// 仍未触发也未取消的采样条目；report 为 nil 时只能通过 Leaks 查询
+// 采样会在添加路径上捕获调用栈，生产环境应使用较大的采样间隔
+func WithLeakDetection(minAge time.Duration, sampleEvery int, report func([]Leak)) Option {
+	return func(t *Timer) {
+		t.leaks = &leakDetector{
+			minAge: minAge,
+			every:  uint64(max(sampleEvery, 1)),
+			report: report,
+		}
+	}
+}
+
+// sample 由 newEntry 调用，按采样率记录条目
+func (d *leakDetector) sample(e *Entry, now time.Time) {
+	if d.counter.Add(1)%d.every != 0 {
+		return
+	}
+	pcs := make([]uintptr, 32)
+	// 跳过 Callers、sample 与 newEntry
+	n := runtime.Callers(3, pcs)
+	d.samples.Store(e.id, &leakSample{ref: e.Ref(), created: now, stack: pcs[:n]})
+}
+
+// forget 条目交给 handler 后不再跟踪
+func (d *leakDetector) forget(e *Entry) {
+	d.samples.Delete(e.id)
+}
+
+// Leaks 返回疑似泄漏的采样条目，按创建时间排序，未开启泄漏检测时返回 nil
+func (t *Timer) Leaks() []Leak {
+	if t.leaks == nil {
+		return nil
+	}
+	var leaks []Leak
+	t.inspect(func() {
+		leaks = t.collectLeaks()
+	})
+	return leaks
+}
+
+// collectLeaks 仅在 run loop 中或 run loop 停止后调用
+func (t *Timer) collectLeaks() []Leak {
+	d := t.leaks
+	now := t.now()
+	var leaks []Leak
+	t.walkPending(func(e *Entry) {
+		v, ok := d.samples.Load(e.id)
+		if !ok || e.IsDone() {
+			return
+		}
+		s := v.(*leakSample)
+		if now.Sub(s.created) < d.minAge {
+			return
+		}
+		leaks = append(leaks, Leak{
+			ID:       e.id,
+			Tag:      e.tag,
+			Created:  s.created,
+			Deadline: e.expireAt,
+			Stack:    formatStack(s.stack),
+		})
+	})
+
+	// 清理已离开定时器（如被迁移或交出）的条目
+	d.samples.Range(func(k, v any) bool {
+		if v.(*leakSample).ref.State() == StateRecycled {
+			d.samples.Delete(k)
+		}
+		return true
+	})
+
+	slices.SortFunc(leaks, func(a, b Leak) int {
+		return a.Created.Compare(b.Created)
+	})
+	return leaks
+}
+
+// reportLeaks Stop 时报告疑似泄漏
+func (t *Timer) reportLeaks() {
+	if t.leaks == nil || t.leaks.report == nil {
+		return
+	}
+	if leaks := t.collectLeaks(); len(leaks) > 0 {
+		t.leaks.report(leaks)
+	}
+}
+
+func formatStack(pcs []uintptr) string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package whTimer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimerLeakDetection(t *testing.T) {
+	var reported []Leak
+	timer := NewTimer(func(e *Entry) { e.Execute() },
+		WithLeakDetection(time.Minute, 1, func(leaks []Leak) { reported = leaks }))
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	leaked := timer.AddTaggedEntry("leaked", time.Hour, func() {})
+	timer.AddEntry(time.Hour, func() {}).Cancel()
+	timer.AddEntry(time.Second, func() {})
+
+	timer.Advance(base.Add(30 * time.Second))
+	if leaks := timer.Leaks(); len(leaks) != 0 {
+		t.Fatalf("entries younger than minAge reported: %+v", leaks)
+	}
+
+	timer.Advance(base.Add(2 * time.Minute))
+	leaks := timer.Leaks()
+	if len(leaks) != 1 || leaks[0].ID != leaked.ID() || leaks[0].Tag != "leaked" || !leaks[0].Created.Equal(base) {
+		t.Fatalf("leaks=%+v", leaks)
+	}
+	if !strings.Contains(leaks[0].Stack, "TestTimerLeakDetection") {
+		t.Fatalf("stack does not include the caller:\n%s", leaks[0].Stack)
+	}
+
+	// Stop 时通过回调报告
+	running := NewTimer(func(e *Entry) { e.Execute() },
+		WithLeakDetection(0, 1, func(leaks []Leak) { reported = leaks }))
+	running.Start()
+	running.AddEntry(time.Hour, func() {})
+	running.Stop()
+	if len(reported) != 1 {
+		t.Fatalf("reported=%+v", reported)
+	}
+}
@@ -0,0 +1,27 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// Advance 手动驱动定时器到 now，在调用方 goroutine 上同步触发所有到期任务，返回触发数量
+// 适合游戏服务器等自带帧循环的单线程场景，无需 Start 启动内部 goroutine；
+// 首次调用后定时器进入手动模式，AddEntry 的延迟均相对最近一次 Advance 的时间计算
+func (t *Timer) Advance(now time.Time) int {
+	if t.running.Load() {
+		panic("whTimer: Advance called on a started Timer")
+	}
+
+	t.manualNow.Store(now.UnixNano())
+	before := t.fired
+
+	// 与 run loop 执行相同的处理阶段；到期任务的回调可能继续添加已到期的任务，继续处理直到队列排空，
+	// 至多 maxCycleRounds 轮，回调不断添加已到期的任务时余下的留给下一次 Advance，不会卡住调用方的帧循环
+	for range maxCycleRounds {
+		t.runCycle()
+		if t.queue.IsEmpty() && len(t.rearm) == 0 && len(t.order.expired) == 0 {
+			break
+		}
+	}
+	return int(t.fired - before)
+}
@@ -0,0 +1,56 @@
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerAdvance(t *testing.T) {
+	var fired []int
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	})
+
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+	for i := 1; i <= 3; i++ {
+		timer.AddEntry(time.Duration(i)*100*time.Millisecond, func() {
+			fired = append(fired, i)
+		})
+	}
+
+	if n := timer.Advance(base.Add(50 * time.Millisecond)); n != 0 {
+		t.Errorf("expected 0 fired, got %d", n)
+	}
+	if n := timer.Advance(base.Add(250 * time.Millisecond)); n != 2 {
+		t.Errorf("expected 2 fired, got %d", n)
+	}
+	if n := timer.Advance(base.Add(time.Second)); n != 1 {
+		t.Errorf("expected 1 fired, got %d", n)
+	}
+	if len(fired) != 3 || fired[0] != 1 || fired[2] != 3 {
+		t.Errorf("unexpected fire order %v", fired)
+	}
+}
+
+func TestTimerAdvanceBounded(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	// 回调不断添加已到期的任务：Advance 仍须返回，余下的留给下一次调用
+	var fired int
+	var again func()
+	again = func() {
+		fired++
+		timer.AddEntry(0, again)
+	}
+	timer.AddEntry(0, again)
+	if n := timer.Advance(base); n == 0 || n != fired {
+		t.Fatalf("Advance fired %d, callbacks ran %d", n, fired)
+	}
+	prev := fired
+	if n := timer.Advance(base); n == 0 || fired != prev+n {
+		t.Fatalf("second Advance fired %d, callbacks ran %d after %d", n, fired, prev)
+	}
+}
@@ -0,0 +1,39 @@
+package whTimer
+
+// EntryHandler 处理条目的函数，Use 注册的中间件以它为单位层层包装
+type EntryHandler func(*Entry)
+
+// Use 注册回调中间件，包装之后每个条目回调的执行，用于统一的指标、panic 恢复、追踪与日志
+// 先注册的位于外层；中间件在 Execute 中、回调执行前后运行，next 执行条目的回调或 Runnable，
+// 不调用 next 即跳过回调。可在运行中调用，对之后开始执行的回调生效
+func (t *Timer) Use(mw ...func(next EntryHandler) EntryHandler) {
+	t.middlewareMu.Lock()
+	defer t.middlewareMu.Unlock()
+
+	t.middlewares = append(t.middlewares, mw...)
+	chain := EntryHandler(runEntry)
+	for i := len(t.middlewares) - 1; i >= 0; i-- {
+		chain = t.middlewares[i](chain)
+	}
+	t.chain.Store(&chain)
+}
+
+// wrap 以 inner 为最内层组装当前注册的中间件链，未注册中间件时返回 inner
+func (t *Timer) wrap(inner EntryHandler) EntryHandler {
+	t.middlewareMu.Lock()
+	defer t.middlewareMu.Unlock()
+
+	for i := len(t.middlewares) - 1; i >= 0; i-- {
+		inner = t.middlewares[i](inner)
+	}
+	return inner
+}
+
+// runEntry 执行条目的回调或 Runnable，是中间件链的最内层
+func runEntry(e *Entry) {
+	if e.callback != nil {
+		e.callback()
+	} else if e.runnable != nil {
+		e.runnable.Run()
+	}
+}
@@ -0,0 +1,45 @@
+package whTimer
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimerUse(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	var log []string
+	trace := func(name string) func(EntryHandler) EntryHandler {
+		return func(next EntryHandler) EntryHandler {
+			return func(e *Entry) {
+				log = append(log, name+">"+e.Tag())
+				next(e)
+				log = append(log, name+"<")
+			}
+		}
+	}
+	var recovered any
+	recovery := func(next EntryHandler) EntryHandler {
+		return func(e *Entry) {
+			defer func() { recovered = recover() }()
+			next(e)
+		}
+	}
+	timer.Use(recovery, trace("outer"))
+	timer.Use(trace("inner"))
+
+	timer.AddTaggedEntry("job", 10*time.Millisecond, func() { log = append(log, "run") })
+	timer.AddTaggedEntry("bad", 20*time.Millisecond, func() { panic("boom") })
+	timer.Advance(base.Add(10 * time.Millisecond))
+	want := []string{"outer>job", "inner>job", "run", "inner<", "outer<"}
+	if !slices.Equal(log, want) {
+		t.Fatalf("log=%q", log)
+	}
+	timer.Advance(base.Add(20 * time.Millisecond))
+	if recovered != "boom" {
+		t.Fatalf("recovered=%v", recovered)
+	}
+}
@@ -0,0 +1,111 @@
+package whTimer
+
+// MigrateTo 将满足 pred 的未触发条目（包括仍在队列中的）迁移到 dst，返回迁移数量
+// 在 run loop 中执行，迁移期间不会有条目被漏触发或重复触发，适合运行时在分片间重新均衡；
+// 需要重建时间轮，耗时与待处理任务数成正比；在 handler 中调用时不执行，返回 0
+func (t *Timer) MigrateTo(dst *Timer, pred func(*Entry) bool) int {
+	if dst == t {
+		return 0
+	}
+
+	moved := 0
+	t.inspect(func() {
+		if !t.idle() {
+			return
+		}
+		var keep []*Entry
+		t.drainAll(func(e *Entry) {
+			if e.IsDone() {
+				if e.detach() {
+					e.tryRecycle()
+				}
+				return
+			}
+			if at, ok := e.takeDeadline(); ok {
+				e.expireAt = at
+			}
+			if !pred(e) {
+				keep = append(keep, e)
+				return
+			}
+			e.detach()
+			if dst.Adopt(e) {
+				moved++
+			}
+		})
+		for _, e := range keep {
+			t.addToWheel(e)
+		}
+	})
+	return moved
+}
+
+// Detach 将未触发的条目移出所属定时器，截止时间、标签与编号保持不变，之后可由其他 Timer 的 Adopt 继续调度
+// 例如连接移交到另一个分片时随之转移其超时，无需取消后重建；在所属定时器的 run loop 中执行
+// 条目已结束、已到期正在交付（限流、并发上限、关键任务忙等）、已溢出到外部存储或在所属定时器的 handler 中调用时返回 false
+func (e *Entry) Detach() bool {
+	t := e.owner
+	if t == nil || e.IsDone() {
+		return false
+	}
+	ok := false
+	t.inspect(func() {
+		ok = t.idle() && t.detachEntry(e)
+	})
+	return ok
+}
+
+// detachEntry 在 run loop 中将条目从堆或时间轮中摘除
+func (t *Timer) detachEntry(e *Entry) bool {
+	if e.owner != t {
+		return false
+	}
+	// 仍在队列中的条目先放入时间轮，队列不支持从中间移除
+	for t.drainQueue() == maxDrainBatch {
+	}
+	if e.IsDone() || !t.unlink(e) {
+		return false
+	}
+	if t.leaks != nil {
+		t.leaks.forget(e)
+	}
+	e.detach()
+	return true
+}
+
+// unlink 从堆或时间轮中移除条目，返回是否找到
+func (t *Timer) unlink(e *Entry) bool {
+	for i, x := range t.heap {
+		if x == e {
+			t.heap.remove(t, i)
+			return true
+		}
+	}
+	if t.wheel == nil {
+		return false
+	}
+
+	// 先按截止时间推算所在槽位，推算不到时（如从堆迁入时已过期）遍历查找
+	found := false
+	if due := t.dueAt(e); due.After(t.start) {
+		found = t.wheel.Unlink(e, ceilMs(due.Sub(t.start)))
+	}
+	if !found {
+		t.wheel.Walk(func(x *Entry, ms uint64) bool {
+			if x == e {
+				found = t.wheel.Unlink(e, ms)
+				return false
+			}
+			return true
+		})
+	}
+	if !found {
+		return false
+	}
+	t.numEntries--
+	if t.wheel.Empty() {
+		t.wheel = nil
+		t.numEntries = 0
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package whTimer
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimerMigrateTo(t *testing.T) {
+	src := NewTimer(func(e *Entry) { e.Execute() })
+	dst := NewTimer(func(e *Entry) { e.Execute() })
+	src.Start()
+	dst.Start()
+	defer src.Stop()
+	defer dst.Stop()
+
+	var fromSrc atomic.Int32
+	var wg sync.WaitGroup
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		tag := "stay"
+		if i%2 == 0 {
+			tag = "move"
+		}
+		src.AddTaggedEntry(tag, 30*time.Millisecond, func() { fromSrc.Add(1); wg.Done() })
+	}
+
+	moved := src.MigrateTo(dst, func(e *Entry) bool { return e.Tag() == "move" })
+	if moved != 5 {
+		t.Fatalf("moved=%d, want 5", moved)
+	}
+	if n := len(src.Upcoming(10)); n != 5 {
+		t.Fatalf("src upcoming=%d, want 5", n)
+	}
+	if n := len(dst.Upcoming(10)); n != 5 {
+		t.Fatalf("dst upcoming=%d, want 5", n)
+	}
+	wg.Wait()
+	if fromSrc.Load() != 10 {
+		t.Fatalf("fired=%d, want 10", fromSrc.Load())
+	}
+}
+
+func TestEntryDetach(t *testing.T) {
+	base := time.Unix(1000, 0)
+	for _, opts := range [][]Option{nil, {WithSmallHeap(8)}} {
+		src := NewTimer(func(e *Entry) { e.Execute() }, opts...)
+		dst := NewTimer(func(e *Entry) { e.Execute() })
+		src.Advance(base)
+		dst.Advance(base)
+
+		var fired []string
+		for _, d := range []int{10, 30, 50} {
+			src.AddEntry(time.Duration(d)*time.Millisecond, func() { fired = append(fired, "src") })
+		}
+		conn := src.AddTaggedEntry("conn-7", 40*time.Millisecond, func() { fired = append(fired, "conn") })
+		id, deadline := conn.ID(), conn.ExpireAt()
+		src.Advance(base.Add(5 * time.Millisecond))
+
+		if !conn.Detach() {
+			t.Fatal("Detach failed for pending entry")
+		}
+		if conn.Detach() {
+			t.Fatal("entry detached twice")
+		}
+		if n := src.Pending(); n != 3 {
+			t.Fatalf("src pending = %d, want 3", n)
+		}
+		if err := src.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		if !dst.Adopt(conn) || conn.ID() != id || conn.Tag() != "conn-7" || !conn.ExpireAt().Equal(deadline) {
+			t.Fatal("adopted entry lost its identity")
+		}
+
+		src.Advance(base.Add(100 * time.Millisecond))
+		dst.Advance(base.Add(100 * time.Millisecond))
+		if !slices.Equal(fired, []string{"src", "src", "src", "conn"}) {
+			t.Fatalf("fired = %v", fired)
+		}
+	}
+}
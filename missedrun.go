@@ -0,0 +1,53 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// missedRunWatch WithOnMissedRun 的配置，创建后只读，添加周期任务的 goroutine 也会读取
+type missedRunWatch struct {
+	grace time.Duration
+	fn    func(job *CronEntry, expected time.Time)
+}
+
+// WithOnMissedRun 为每个周期任务的每次发生设置看门狗：预定时间 expected 之后 grace 内仍未开始执行时调用 fn
+// 用于发现 run loop 停顿、任务被 BeforeFire 否决或被取消等静默的调度失败，便于接入告警
+// 看门狗基于 Go 运行时定时器而非本定时器，run loop 停顿时仍能触发；fn 在独立的 goroutine 中调用
+// 手动驱动模式与虚拟时间暂停时不设看门狗
+func WithOnMissedRun(grace time.Duration, fn func(job *CronEntry, expected time.Time)) Option {
+	return func(t *Timer) {
+		t.missedRun.grace = max(grace, 0)
+		t.missedRun.fn = fn
+	}
+}
+
+// watchRun 为 at 处的发生设置看门狗，返回包装后的回调，执行时先记录已运行
+func (c *CronEntry) watchRun(at time.Time, fn func()) func() {
+	t := c.timer
+	if t.missedRun.fn == nil || t.manualNow.Load() != 0 {
+		return fn
+	}
+
+	wall := at
+	if clock := t.clock.Load(); clock != nil {
+		var ok bool
+		if wall, ok = clock.toWall(at); !ok {
+			return fn
+		}
+	}
+
+	expected := at.UnixNano()
+	watchdog := time.AfterFunc(time.Until(wall)+t.missedRun.grace, func() {
+		if !c.stopped.Load() && c.ran.Load() < expected {
+			t.missedRun.fn(c, at)
+		}
+	})
+	if old := c.watchdog.Swap(watchdog); old != nil {
+		old.Stop()
+	}
+
+	return func() {
+		c.ran.Store(expected)
+		fn()
+	}
+}
@@ -0,0 +1,48 @@
+package whTimer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimerOnMissedRun(t *testing.T) {
+	type missed struct {
+		job      *CronEntry
+		expected time.Time
+	}
+	events := make(chan missed, 4)
+	onMissed := func(job *CronEntry, expected time.Time) { events <- missed{job, expected} }
+
+	// run loop 未启动：发生未执行，grace 之后报告
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithOnMissedRun(20*time.Millisecond, onMissed))
+	at := time.Now().Add(10 * time.Millisecond)
+	job := timer.CronAt(at, func() {})
+	select {
+	case ev := <-events:
+		if ev.job != job || !ev.expected.Equal(at) {
+			t.Fatalf("missed event = %+v, want job at %v", ev, at)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("missed run not reported")
+	}
+
+	// 正常执行与已停止的任务不报告；grace 远大于调度抖动，负载较高时 run loop 的延迟不会被误报，
+	// 周期任务持续运行超过 grace，前几次发生的看门狗在停止前已经到期
+	timer = NewTimer(func(e *Entry) { e.Execute() }, WithOnMissedRun(250*time.Millisecond, onMissed))
+	timer.Start()
+	defer timer.Stop()
+	var runs atomic.Int32
+	ran := timer.CronInterval(10*time.Millisecond, func() { runs.Add(1) })
+	stopped := timer.CronAt(time.Now().Add(10*time.Millisecond), func() {})
+	stopped.Stop()
+	select {
+	case ev := <-events:
+		t.Fatalf("unexpected missed event for %p at %v", ev.job, ev.expected)
+	case <-time.After(350 * time.Millisecond):
+	}
+	ran.Stop()
+	if runs.Load() == 0 {
+		t.Fatal("interval job never ran")
+	}
+}
@@ -0,0 +1,129 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// Option 定时器配置项
+type Option func(*Timer)
+
+// WithPooling 是否通过对象池复用 Entry，默认开启
+// 关闭后条目由 GC 管理，可彻底排除释放后使用问题，也便于竞态检测器观察
+func WithPooling(enabled bool) Option {
+	return func(t *Timer) {
+		t.noPooling = !enabled
+	}
+}
+
+// WithOnDemand 按需运行 run loop，默认关闭
+// 开启后时间轮与队列均为空时 goroutine 直接退出，下次添加任务时再惰性启动，
+// 适合嵌入默认 Timer 但很少调度任务的库
+func WithOnDemand(enabled bool) Option {
+	return func(t *Timer) {
+		t.onDemand = enabled
+	}
+}
+
+// WithBusyPoll 下次过期时间在 threshold 以内时忙等而不是进入运行时定时器，默认关闭
+// 可将触发抖动降到 100µs 以下，代价是等待期间占满一个 CPU 核心
+// 等价于 WithWaitStrategy(HybridWait{SpinThreshold: threshold})
+func WithBusyPoll(threshold time.Duration) Option {
+	return WithWaitStrategy(HybridWait{SpinThreshold: threshold})
+}
+
+// WithWaitStrategy 设置 run loop 等待策略，默认 SleepWait
+func WithWaitStrategy(s WaitStrategy) Option {
+	return func(t *Timer) {
+		t.wait = s
+		t.hotOption++
+	}
+}
+
+// WithLockOSThread 将 run loop goroutine 固定在一个 OS 线程上，默认关闭
+// 避免调度器在线程间迁移 run loop，降低延迟敏感场景的唤醒抖动
+func WithLockOSThread(enabled bool) Option {
+	return func(t *Timer) {
+		t.lockOSThread = enabled
+	}
+}
+
+// WithCPUAffinity 将 run loop 所在线程绑定到指定 CPU，隐含 WithLockOSThread(true)
+// 仅 Linux 生效，其他平台或设置失败时 run loop 照常运行，错误由 CPUAffinityErr 返回；
+// 绑定过的线程在 run loop 退出时随之销毁，不会交还调度器运行其他 goroutine
+func WithCPUAffinity(cpus ...int) Option {
+	return func(t *Timer) {
+		t.lockOSThread = true
+		t.cpuAffinity = cpus
+	}
+}
+
+// WithHighResolution 定时器运行期间请求高精度系统时钟，默认关闭
+// Windows 默认时钟分辨率约 15.6ms，会使 1ms 时间轮失去意义，开启后通过
+// timeBeginPeriod(1) 提升到约 1ms（进程级设置，会略微增加功耗）；其他平台无影响
+func WithHighResolution(enabled bool) Option {
+	return func(t *Timer) {
+		t.highResolution = enabled
+	}
+}
+
+// WithCriticalLead 关键任务提前离开时间轮进入忙等阶段的时长，默认 500µs
+// 需覆盖运行时定时器的唤醒误差，越大精度越稳定，忙等占用的 CPU 也越多
+func WithCriticalLead(d time.Duration) Option {
+	return func(t *Timer) {
+		t.criticalLead = d
+		t.hotOption++
+	}
+}
+
+// WithFireTimestamps 触发时记录每个条目的实际触发时间，默认关闭
+// 开启后可通过 Entry.FiredAt 与 Entry.Lateness 获取调度延迟，每次触发多一次读时钟
+func WithFireTimestamps(enabled bool) Option {
+	return func(t *Timer) {
+		t.fireTimestamps = enabled
+		t.hotOption++
+	}
+}
+
+// WithOnClamp 过期时间超出 MaxDuration 的条目被截断时在 run loop 中调用 fn
+// requested 为原过期时间，条目的过期时间已改为截断后的值；使用 TryAddEntry 可在添加时得到错误
+func WithOnClamp(fn func(entry *Entry, requested time.Time)) Option {
+	return func(t *Timer) {
+		t.hooks.onClamp = fn
+	}
+}
+
+// WithOnLate 条目触发时晚于过期时间超过 threshold 时，在交给 handler 前于 run loop 中调用 fn
+// 使 SLO 违约以事件形式呈现，fn 不应阻塞
+func WithOnLate(threshold time.Duration, fn func(entry *Entry, drift time.Duration)) Option {
+	return func(t *Timer) {
+		t.hooks.lateThreshold = threshold
+		t.hooks.onLate = fn
+		t.hotOption++
+	}
+}
+
+// WithSuspendDetection 实际唤醒晚于预期超过 threshold 时视为系统挂起后恢复，默认关闭
+func WithSuspendDetection(threshold time.Duration) Option {
+	return func(t *Timer) {
+		t.resume.threshold = threshold
+		t.hotOption++
+	}
+}
+
+// WithResumePolicy 设置挂起恢复后错过任务的处理策略
+// window 为 ResumeSpread 策略下分散触发的时间窗口
+func WithResumePolicy(policy ResumePolicy, window time.Duration) Option {
+	return func(t *Timer) {
+		t.resume.policy = policy
+		t.resume.window = window
+		t.hotOption++
+	}
+}
+
+// WithOnResume 设置挂起恢复事件回调，在 run loop 中同步调用
+func WithOnResume(fn func(ResumeEvent)) Option {
+	return func(t *Timer) {
+		t.resume.onResume = fn
+		t.hotOption++
+	}
+}
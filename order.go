@@ -0,0 +1,49 @@
+package whTimer
+
+import (
+	"cmp"
+	"slices"
+)
+
+// stableOrder WithStableOrder 的开关与本轮收集、等待排序交付的到期任务
+type stableOrder struct {
+	enabled bool
+	expired []*Entry
+}
+
+// WithStableOrder 每轮处理到期任务时先按精确的过期时间（相同时按创建顺序）排序再交给 handler，默认关闭
+// 时间轮按 1ms 槽位触发，同一槽位内的任务以及放入时已到期的任务不保证先后；
+// 开启后过期时间为 10:00:00.001 的任务不会晚于 10:00:00.002 的任务交付，适合对顺序有假设的状态机
+// handler 异步执行 Execute 时执行顺序仍由 handler 决定
+func WithStableOrder(enabled bool) Option {
+	return func(t *Timer) {
+		t.order.enabled = enabled
+	}
+}
+
+// expire 处理一个到期任务，开启 WithStableOrder 时先收集，由 flushExpired 排序后统一交付
+func (t *Timer) expire(entry *Entry) {
+	if t.order.enabled {
+		t.order.expired = append(t.order.expired, entry)
+		return
+	}
+	t.dispatch(entry)
+}
+
+// flushExpired 按过期时间与创建顺序交付收集的到期任务
+func (t *Timer) flushExpired() {
+	if len(t.order.expired) == 0 {
+		return
+	}
+	slices.SortFunc(t.order.expired, func(a, b *Entry) int {
+		if c := a.expireAt.Compare(b.expireAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.id, b.id)
+	})
+	for i, e := range t.order.expired {
+		t.order.expired[i] = nil
+		t.dispatch(e)
+	}
+	t.order.expired = t.order.expired[:0]
+}
@@ -0,0 +1,28 @@
+package whTimer
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimerStableOrder(t *testing.T) {
+	base := time.Unix(1000, 0)
+	var order []int
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithStableOrder(true))
+	timer.Advance(base)
+
+	// 同一毫秒槽位内后加入的任务位于链表头部，默认先交付
+	for i, us := range []int{900, 300, 600, 100} {
+		timer.AddEntryAt(base.Add(5*time.Millisecond+time.Duration(us)*time.Microsecond), func() { order = append(order, i) })
+	}
+	timer.Advance(base.Add(4 * time.Millisecond))
+	// 放入时已到期的任务与时间轮中的到期任务一起排序
+	timer.AddEntryAt(base.Add(5*time.Millisecond+200*time.Microsecond), func() { order = append(order, 4) })
+	timer.AddEntryAt(base.Add(5*time.Millisecond+200*time.Microsecond), func() { order = append(order, 5) })
+	timer.Advance(base.Add(7 * time.Millisecond))
+
+	if want := []int{3, 4, 5, 1, 2, 0}; !slices.Equal(order, want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+}
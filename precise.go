@@ -0,0 +1,74 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// defaultCriticalLead 关键任务默认提前离开时间轮的时长
+const defaultCriticalLead = 500 * time.Microsecond
+
+// AddCritical 添加需要精确触发的关键任务 - Wait-Free
+// 时间轮负责粗粒度定位，任务在到期前 criticalLead 离开时间轮，
+// 随后由 run loop 忙等到精确时刻再交给 handler，触发误差可降到微秒以下。
+// 忙等会占用 CPU，仅适合少量关键任务
+func (t *Timer) AddCritical(delay time.Duration, callback func()) *Entry {
+	return t.AddCriticalAt(t.now().Add(delay), callback)
+}
+
+// AddCriticalAt 在指定时间添加需要精确触发的关键任务 - Wait-Free
+func (t *Timer) AddCriticalAt(expireAt time.Time, callback func()) *Entry {
+	entry := t.newEntry(expireAt, callback)
+	entry.delay = expireAt.Sub(t.now())
+	entry.critical = true
+	return t.push(entry)
+}
+
+// holdPrecise 尚未到达精确时刻的关键任务进入精确阶段，返回是否已暂存
+func (t *Timer) holdPrecise(entry *Entry) bool {
+	if !t.now().Before(entry.expireAt) {
+		return false
+	}
+
+	i := len(t.precise)
+	for i > 0 && t.precise[i-1].expireAt.After(entry.expireAt) {
+		i--
+	}
+	t.precise = append(t.precise, nil)
+	copy(t.precise[i+1:], t.precise[i:])
+	t.precise[i] = entry
+	return true
+}
+
+// firePrecise 触发已到达精确时刻的关键任务
+func (t *Timer) firePrecise() {
+	if len(t.precise) == 0 {
+		return
+	}
+
+	now := t.now()
+	n := 0
+	for n < len(t.precise) && !t.precise[n].expireAt.After(now) {
+		n++
+	}
+	if n == 0 {
+		return
+	}
+
+	due := t.precise[:n]
+	for _, entry := range due {
+		entry.critical = false
+		t.dispatch(entry)
+	}
+	rest := copy(t.precise, t.precise[n:])
+	clear(t.precise[rest:])
+	t.precise = t.precise[:rest]
+}
+
+// nextPrecise 返回精确阶段中最早的触发时间
+func (t *Timer) nextPrecise() *time.Time {
+	if len(t.precise) == 0 {
+		return nil
+	}
+	result := t.precise[0].expireAt
+	return &result
+}
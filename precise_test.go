@@ -0,0 +1,49 @@
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerAddCritical(t *testing.T) {
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	})
+	timer.Start()
+	defer timer.Stop()
+
+	late := make(chan time.Duration, 1)
+	expireAt := time.Now().Add(20 * time.Millisecond)
+	timer.AddCriticalAt(expireAt, func() {
+		late <- time.Since(expireAt)
+	})
+
+	select {
+	case d := <-late:
+		if d < 0 {
+			t.Errorf("critical entry fired %v early", -d)
+		}
+		if d > 5*time.Millisecond {
+			t.Errorf("critical entry fired %v late", d)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("critical entry did not fire")
+	}
+}
+
+func TestTimerAdvanceCritical(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	// 提前离开时间轮后进入精确阶段，之后的 Advance 到达精确时刻时触发
+	var fired int
+	timer.AddCritical(10*time.Millisecond, func() { fired++ })
+	timer.Advance(base.Add(10*time.Millisecond - defaultCriticalLead/2))
+	if fired != 0 {
+		t.Fatal("critical entry fired before its deadline")
+	}
+	if n := timer.Advance(base.Add(10 * time.Millisecond)); n != 1 || fired != 1 {
+		t.Fatalf("critical entry in manual mode: Advance=%d fired=%d", n, fired)
+	}
+}
@@ -0,0 +1,36 @@
+//go:build !whtimer_tiny && !tinygo
+
+package whTimer
+
+import (
+	"sync"
+
+	"whTimer/internal/cpu"
+)
+
+// cacheLineSize 缓存行大小，用于隔离不同写入方的字段
+const cacheLineSize = cpu.CacheLineSize
+
+// entryPool 对象池
+var entryPool = sync.Pool{
+	New: func() any {
+		poolCounters.misses.Add(1)
+		return &Entry{}
+	},
+}
+
+func getEntry() *Entry {
+	poolCounters.gets.Add(1)
+	return entryPool.Get().(*Entry)
+}
+
+func putEntry(e *Entry) {
+	poolCounters.puts.Add(1)
+	entryPool.Put(e)
+}
+
+// poolIdle 以放回次数减去复用次数估计空闲条目数
+func poolIdle() int {
+	gets, misses, puts := poolCounters.gets.Load(), poolCounters.misses.Load(), poolCounters.puts.Load()
+	return int(max(int64(puts)-int64(gets-min(misses, gets)), 0))
+}
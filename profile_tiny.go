@@ -0,0 +1,55 @@
+//go:build whtimer_tiny || tinygo
+
+package whTimer
+
+import (
+	"sync"
+
+	"whTimer/internal/cpu"
+)
+
+// cacheLineSize 精简构建不做缓存行填充，Timer 与队列只保留最小间隔
+const cacheLineSize = cpu.CacheLineSize
+
+// TinyPoolSize 精简构建下空闲 Entry 列表的容量上限
+// 超出部分交给 GC 回收，保证对象池占用的内存有确定上界
+const TinyPoolSize = 64
+
+// entryFree 固定容量的空闲列表，替代 TinyGo 上开销较大的 sync.Pool
+var entryFree struct {
+	sync.Mutex
+	n    int
+	list [TinyPoolSize]*Entry
+}
+
+func getEntry() *Entry {
+	poolCounters.gets.Add(1)
+	entryFree.Lock()
+	if entryFree.n > 0 {
+		entryFree.n--
+		e := entryFree.list[entryFree.n]
+		entryFree.list[entryFree.n] = nil
+		entryFree.Unlock()
+		return e
+	}
+	entryFree.Unlock()
+	poolCounters.misses.Add(1)
+	return &Entry{}
+}
+
+func putEntry(e *Entry) {
+	poolCounters.puts.Add(1)
+	entryFree.Lock()
+	if entryFree.n < TinyPoolSize {
+		entryFree.list[entryFree.n] = e
+		entryFree.n++
+	}
+	entryFree.Unlock()
+}
+
+// poolIdle 返回空闲列表中的条目数
+func poolIdle() int {
+	entryFree.Lock()
+	defer entryFree.Unlock()
+	return entryFree.n
+}
@@ -0,0 +1,85 @@
+package whTimer
+
+import (
+	"sync"
+	"time"
+)
+
+// Progress 截止时间前周期性报告进度的逻辑任务，同一时刻只占用一个时间轮条目
+type Progress struct {
+	timer    *Timer
+	deadline time.Time
+	every    time.Duration
+	onTick   func(remaining time.Duration)
+	onDone   func()
+
+	mu    sync.Mutex
+	entry EntryRef
+	done  bool
+}
+
+// ProgressUntil 每隔 every 调用一次 onTick 报告剩余时长，到达 deadline 时调用 onDone（均可为 nil）
+// 进度点按起始时间的整数倍计算，不随回调耗时漂移；落在 deadline 上的进度点由 onDone 代替
+func (t *Timer) ProgressUntil(deadline time.Time, every time.Duration, onTick func(remaining time.Duration), onDone func()) *Progress {
+	p := &Progress{
+		timer:    t,
+		deadline: deadline,
+		every:    max(every, time.Millisecond),
+		onTick:   onTick,
+		onDone:   onDone,
+	}
+	p.mu.Lock()
+	p.arm(t.now().Add(p.every))
+	p.mu.Unlock()
+	return p
+}
+
+// arm 添加下一个进度点，调用方持有锁
+func (p *Progress) arm(at time.Time) {
+	final := !at.Before(p.deadline)
+	if final {
+		at = p.deadline
+	}
+
+	var ref EntryRef
+	_, ref = p.timer.addEntryRef(at, func() {
+		p.mu.Lock()
+		if p.entry != ref || p.done {
+			p.mu.Unlock()
+			return
+		}
+		if final {
+			p.done = true
+			p.mu.Unlock()
+			if p.onDone != nil {
+				p.onDone()
+			}
+			return
+		}
+		p.arm(at.Add(p.every))
+		p.mu.Unlock()
+		if p.onTick != nil {
+			p.onTick(p.deadline.Sub(at))
+		}
+	})
+	p.entry = ref
+}
+
+// Cancel 取消尚未结束的进度任务，之后不再调用 onTick 与 onDone，返回是否成功取消
+func (p *Progress) Cancel() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		return false
+	}
+	p.done = true
+	p.entry.Cancel()
+	return true
+}
+
+// Done 检查是否已结束（到达截止时间或被取消）
+func (p *Progress) Done() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.done
+}
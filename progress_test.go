@@ -0,0 +1,39 @@
+package whTimer
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimerProgressUntil(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	var ticks []time.Duration
+	var done bool
+	p := timer.ProgressUntil(base.Add(100*time.Millisecond), 30*time.Millisecond,
+		func(remaining time.Duration) { ticks = append(ticks, remaining) },
+		func() { done = true })
+	for ms := 10; ms <= 100; ms += 10 {
+		timer.Advance(base.Add(time.Duration(ms) * time.Millisecond))
+	}
+	want := []time.Duration{70 * time.Millisecond, 40 * time.Millisecond, 10 * time.Millisecond}
+	if !slices.Equal(ticks, want) || !done || !p.Done() || p.Cancel() {
+		t.Fatalf("ticks=%v done=%v", ticks, done)
+	}
+
+	ticks = nil
+	canceled := timer.ProgressUntil(base.Add(time.Second), 50*time.Millisecond,
+		func(remaining time.Duration) { ticks = append(ticks, remaining) },
+		func() { t.Error("onDone after Cancel") })
+	timer.Advance(base.Add(150 * time.Millisecond))
+	if !canceled.Cancel() {
+		t.Fatal("Cancel")
+	}
+	timer.Advance(base.Add(2 * time.Second))
+	if len(ticks) != 1 {
+		t.Fatalf("ticks after Cancel=%v", ticks)
+	}
+}
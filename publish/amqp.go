@@ -0,0 +1,33 @@
+package publish
+
+import (
+	"context"
+	"time"
+
+	"whTimer"
+)
+
+// AMQPPublishFunc 通过用户提供的 AMQP channel 发布消息，例如 amqp091-go:
+//
+//	func(ctx context.Context, exchange, key string, body []byte) error {
+//		return ch.PublishWithContext(ctx, exchange, key, false, false, amqp.Publishing{Body: body})
+//	}
+type AMQPPublishFunc func(ctx context.Context, exchange, routingKey string, body []byte) error
+
+// AMQP 延迟投递助手，以时间轮精确延迟替代 TTL + 死信交换机的做法
+type AMQP struct {
+	emitter *Emitter
+}
+
+// NewAMQP 创建 AMQP 延迟投递助手，opts 同 NewEmitter（错误回调中 Topic 为交换机，Key 为路由键）
+func NewAMQP(timer *whTimer.Timer, publish AMQPPublishFunc, opts ...Option) *AMQP {
+	pub := PublisherFunc(func(ctx context.Context, m Message) error {
+		return publish(ctx, m.Topic, string(m.Key), m.Value)
+	})
+	return &AMQP{emitter: NewEmitter(timer, pub, opts...)}
+}
+
+// PublishDelayed 延迟 delay 后将 body 发布到 exchange，返回的条目可用于取消
+func (a *AMQP) PublishDelayed(exchange, routingKey string, body []byte, delay time.Duration) *whTimer.Entry {
+	return a.emitter.PublishAfter(delay, Message{Topic: exchange, Key: []byte(routingKey), Value: body})
+}
@@ -0,0 +1,97 @@
+// Package publish 基于 whTimer 的延迟消息发布，为不支持原生延迟投递的消息队列
+// （NATS、Kafka 等）在到期时刻将消息发布到指定主题
+package publish
+
+import (
+	"context"
+	"time"
+
+	"whTimer"
+)
+
+// Message 待发布的消息
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// Publisher 消息发布接口，由各消息队列客户端适配实现，例如:
+//
+//	// NATS
+//	publish.PublisherFunc(func(ctx context.Context, m publish.Message) error {
+//		return nc.Publish(m.Topic, m.Value)
+//	})
+//	// Kafka (segmentio/kafka-go)
+//	publish.PublisherFunc(func(ctx context.Context, m publish.Message) error {
+//		return w.WriteMessages(ctx, kafka.Message{Topic: m.Topic, Key: m.Key, Value: m.Value})
+//	})
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// PublisherFunc 函数形式的 Publisher
+type PublisherFunc func(ctx context.Context, msg Message) error
+
+// Publish 实现 Publisher
+func (f PublisherFunc) Publish(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
+
+// Emitter 延迟消息发布器
+type Emitter struct {
+	timer   *whTimer.Timer
+	pub     Publisher
+	timeout time.Duration
+	onError func(Message, error)
+}
+
+// Option 发布器配置项
+type Option func(*Emitter)
+
+// WithTimeout 单次发布超时，默认 10s
+func WithTimeout(d time.Duration) Option {
+	return func(e *Emitter) {
+		e.timeout = d
+	}
+}
+
+// WithErrorHandler 发布失败时调用
+func WithErrorHandler(fn func(Message, error)) Option {
+	return func(e *Emitter) {
+		e.onError = fn
+	}
+}
+
+// NewEmitter 创建延迟消息发布器，timer 需由调用方启动
+func NewEmitter(timer *whTimer.Timer, pub Publisher, opts ...Option) *Emitter {
+	e := &Emitter{timer: timer, pub: pub, timeout: 10 * time.Second}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// PublishAt 在 at 时刻发布消息，返回的条目可用于取消
+// 消息在独立 goroutine 中发布，不阻塞 run loop
+func (e *Emitter) PublishAt(at time.Time, msg Message) *whTimer.Entry {
+	return e.timer.AddTaggedEntryAt(msg.Topic, at, func() {
+		go e.publish(msg)
+	})
+}
+
+// PublishAfter 延迟 delay 后发布消息
+func (e *Emitter) PublishAfter(delay time.Duration, msg Message) *whTimer.Entry {
+	return e.timer.AddTaggedEntry(msg.Topic, delay, func() {
+		go e.publish(msg)
+	})
+}
+
+func (e *Emitter) publish(msg Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	if err := e.pub.Publish(ctx, msg); err != nil && e.onError != nil {
+		e.onError(msg, err)
+	}
+}
@@ -0,0 +1,69 @@
+package publish
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"whTimer"
+)
+
+func TestEmitter(t *testing.T) {
+	timer := whTimer.NewTimer(func(e *whTimer.Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	published := make(chan Message, 2)
+	failed := make(chan error, 1)
+	pub := PublisherFunc(func(ctx context.Context, m Message) error {
+		if m.Topic == "bad" {
+			return errors.New("broker down")
+		}
+		published <- m
+		return nil
+	})
+	e := NewEmitter(timer, pub, WithErrorHandler(func(_ Message, err error) { failed <- err }))
+
+	start := time.Now()
+	e.PublishAfter(20*time.Millisecond, Message{Topic: "orders", Value: []byte("late")})
+	e.PublishAfter(time.Hour, Message{Topic: "orders"}).Cancel()
+	e.PublishAt(time.Now().Add(5*time.Millisecond), Message{Topic: "bad"})
+
+	select {
+	case m := <-published:
+		if string(m.Value) != "late" || time.Since(start) < 20*time.Millisecond {
+			t.Fatalf("published %q after %v", m.Value, time.Since(start))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message not published")
+	}
+	select {
+	case <-failed:
+	case <-time.After(time.Second):
+		t.Fatal("publish error not reported")
+	}
+}
+
+func TestAMQP(t *testing.T) {
+	timer := whTimer.NewTimer(func(e *whTimer.Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	type delivery struct{ exchange, key, body string }
+	got := make(chan delivery, 1)
+	a := NewAMQP(timer, func(ctx context.Context, exchange, key string, body []byte) error {
+		got <- delivery{exchange, key, string(body)}
+		return nil
+	})
+
+	a.PublishDelayed("events", "order.expired", []byte("42"), 10*time.Millisecond)
+	select {
+	case d := <-got:
+		if d != (delivery{"events", "order.expired", "42"}) {
+			t.Fatalf("delivery=%+v", d)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("AMQP message not published")
+	}
+}
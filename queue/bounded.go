@@ -0,0 +1,66 @@
+package queue
+
+import (
+	"sync/atomic"
+)
+
+// Bounded 有容量上限的 MPSC 队列
+// 通过计数限制在途元素数量，队列满时 TryPush 直接失败而不阻塞
+type Bounded[T any] struct {
+	q        *MPSC[T]
+	size     atomic.Int64
+	capacity int64
+}
+
+// NewBounded 创建容量为 capacity 的队列
+func NewBounded[T any](capacity int) *Bounded[T] {
+	if capacity <= 0 {
+		panic("queue: capacity must be positive")
+	}
+	return &Bounded[T]{
+		q:        NewMPSC[T](),
+		capacity: int64(capacity),
+	}
+}
+
+// TryPush 尝试添加元素 - Wait-Free O(1)
+// ok 为 false 表示队列已满，元素未入队
+func (b *Bounded[T]) TryPush(v T) (wasEmpty, ok bool) {
+	if b.size.Add(1) > b.capacity {
+		b.size.Add(-1)
+		return false, false
+	}
+	return b.q.Push(v), true
+}
+
+// PopAll 按入队顺序取出所有元素
+func (b *Bounded[T]) PopAll() []T {
+	var result []T
+	b.DrainAll(func(v T) {
+		result = append(result, v)
+	})
+	return result
+}
+
+// DrainAll 按入队顺序取出并处理所有元素，处理前即释放容量
+func (b *Bounded[T]) DrainAll(fn func(T)) int {
+	return b.q.DrainAll(func(v T) {
+		b.size.Add(-1)
+		fn(v)
+	})
+}
+
+// Len 返回当前元素数量
+func (b *Bounded[T]) Len() int {
+	return int(b.size.Load())
+}
+
+// Cap 返回容量上限
+func (b *Bounded[T]) Cap() int {
+	return int(b.capacity)
+}
+
+// IsEmpty 检查队列是否为空
+func (b *Bounded[T]) IsEmpty() bool {
+	return b.q.IsEmpty()
+}
@@ -0,0 +1,92 @@
+// Package queue 提供 whTimer 内部使用的无锁队列的通用版本
+package queue
+
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"whTimer/internal/cpu"
+)
+
+type node[T any] struct {
+	next  unsafe.Pointer // *node[T]
+	dummy uint32         // 1 表示该节点是当前哑节点，由消费者在推进 tail 前置位
+	value T
+}
+
+// MPSC Wait-Free 多生产者单消费者队列
+// 与 whTimer.MPSCQueue 语义一致，采用带哑节点的 Vyukov 算法，
+// 消费者遇到尚未完成链接的节点时直接返回，永不自旋等待；节点通过内部对象池复用
+type MPSC[T any] struct {
+	head unsafe.Pointer              // *node[T]，生产者端
+	_    [cpu.CacheLineSize - 8]byte // padding
+	tail unsafe.Pointer              // *node[T]，消费者端（哑节点）
+
+	pool sync.Pool
+}
+
+// NewMPSC 创建队列
+func NewMPSC[T any]() *MPSC[T] {
+	q := &MPSC[T]{}
+	q.pool.New = func() any {
+		return &node[T]{}
+	}
+	stub := unsafe.Pointer(&node[T]{dummy: 1})
+	q.head = stub
+	q.tail = stub
+	return q
+}
+
+// Push 添加元素 - Wait-Free O(1)，返回添加前队列是否为空
+// 是否为空由交换得到的前驱节点判断：前驱是哑节点即队列原本为空。
+// 前驱的标记在链接之后读取，读到 0 时消费者尚未推进到前驱，之后一定能看到本节点
+func (q *MPSC[T]) Push(v T) bool {
+	n := q.pool.Get().(*node[T])
+	n.value = v
+	n.next = nil
+	atomic.StoreUint32(&n.dummy, 0)
+
+	prev := (*node[T])(atomic.SwapPointer(&q.head, unsafe.Pointer(n)))
+	atomic.StorePointer(&prev.next, unsafe.Pointer(n))
+	return atomic.LoadUint32(&prev.dummy) == 1
+}
+
+// PopAll 按入队顺序取出所有元素 - Wait-Free
+func (q *MPSC[T]) PopAll() []T {
+	var result []T
+	q.DrainAll(func(v T) {
+		result = append(result, v)
+	})
+	return result
+}
+
+// DrainAll 按入队顺序取出并处理所有元素
+// 遇到生产者尚未完成链接的元素时停止，剩余元素留待下次处理
+func (q *MPSC[T]) DrainAll(fn func(T)) int {
+	count := 0
+	var zero T
+	for {
+		tail := (*node[T])(atomic.LoadPointer(&q.tail))
+		next := (*node[T])(atomic.LoadPointer(&tail.next))
+		if next == nil {
+			return count
+		}
+
+		// next 成为新的哑节点，旧哑节点回收
+		v := next.value
+		next.value = zero
+		atomic.StoreUint32(&next.dummy, 1)
+		atomic.StorePointer(&q.tail, unsafe.Pointer(next))
+		tail.next = nil
+		q.pool.Put(tail)
+
+		fn(v)
+		count++
+	}
+}
+
+// IsEmpty 检查队列是否为空，有生产者正在入队时返回 false
+func (q *MPSC[T]) IsEmpty() bool {
+	return atomic.LoadPointer(&q.head) == atomic.LoadPointer(&q.tail)
+}
@@ -0,0 +1,143 @@
+package queue
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMPSCOrder(t *testing.T) {
+	q := NewMPSC[int]()
+
+	if !q.Push(1) {
+		t.Error("first push should report empty queue")
+	}
+	if q.Push(2) {
+		t.Error("second push should report non-empty queue")
+	}
+	q.Push(3)
+
+	got := q.PopAll()
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+	if !q.IsEmpty() {
+		t.Error("queue should be empty after PopAll")
+	}
+	if !q.Push(4) {
+		t.Error("push after PopAll should report empty queue")
+	}
+}
+
+// TestMPSCWakeup 消费者只在 Push 报告队列原本为空时被唤醒，不能丢失元素
+func TestMPSCWakeup(t *testing.T) {
+	q := NewMPSC[int]()
+	wake := make(chan struct{}, 1)
+
+	const numProducers, perProducer = 4, 10000
+	var wg sync.WaitGroup
+	for i := 0; i < numProducers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perProducer; j++ {
+				if q.Push(j) {
+					select {
+					case wake <- struct{}{}:
+					default:
+					}
+				}
+			}
+		}()
+	}
+
+	total := 0
+	timeout := time.After(10 * time.Second)
+	for total < numProducers*perProducer {
+		select {
+		case <-wake:
+			total += q.DrainAll(func(int) {})
+		case <-timeout:
+			t.Fatalf("lost wakeup: drained %d of %d", total, numProducers*perProducer)
+		}
+	}
+	wg.Wait()
+}
+
+func TestMPSCConcurrentPush(t *testing.T) {
+	q := NewMPSC[int]()
+
+	var wg sync.WaitGroup
+	numProducers := 8
+	perProducer := 1000
+
+	for i := 0; i < numProducers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perProducer; j++ {
+				q.Push(j)
+			}
+		}()
+	}
+
+	total := 0
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	for {
+		select {
+		case <-done:
+			total += q.DrainAll(func(int) {})
+			if total != numProducers*perProducer {
+				t.Errorf("expected %d items, got %d", numProducers*perProducer, total)
+			}
+			return
+		default:
+			total += q.DrainAll(func(int) {})
+		}
+	}
+}
+
+func TestBoundedCapacity(t *testing.T) {
+	q := NewBounded[int](2)
+
+	if _, ok := q.TryPush(1); !ok {
+		t.Error("push within capacity should succeed")
+	}
+	q.TryPush(2)
+	if _, ok := q.TryPush(3); ok {
+		t.Error("push beyond capacity should fail")
+	}
+	if q.Len() != 2 {
+		t.Errorf("expected len 2, got %d", q.Len())
+	}
+
+	if n := q.DrainAll(func(int) {}); n != 2 {
+		t.Errorf("expected 2 drained, got %d", n)
+	}
+	if _, ok := q.TryPush(3); !ok {
+		t.Error("push after drain should succeed")
+	}
+}
+
+func TestShardedPushTo(t *testing.T) {
+	q := NewSharded[int](4)
+
+	for i := 0; i < 8; i++ {
+		q.PushTo(uint64(i), i)
+	}
+
+	for shard := 0; shard < q.NumShards(); shard++ {
+		var got []int
+		q.DrainShard(shard, func(v int) {
+			got = append(got, v)
+		})
+		if len(got) != 2 || got[0] != shard || got[1] != shard+4 {
+			t.Errorf("shard %d: expected [%d %d], got %v", shard, shard, shard+4, got)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package queue
+
+import (
+	"sync/atomic"
+)
+
+// Sharded 按消费者分片的多生产者多消费者队列
+// 每个分片是独立的 MPSC 队列，由固定的一个消费者 goroutine 排空，
+// 多个消费者之间不共享 head 指针
+type Sharded[T any] struct {
+	shards []*MPSC[T]
+	next   atomic.Uint64
+}
+
+// NewSharded 创建包含 n 个分片的队列
+func NewSharded[T any](n int) *Sharded[T] {
+	if n <= 0 {
+		panic("queue: shard count must be positive")
+	}
+	s := &Sharded[T]{shards: make([]*MPSC[T], n)}
+	for i := range s.shards {
+		s.shards[i] = NewMPSC[T]()
+	}
+	return s
+}
+
+// Push 轮询选择分片添加元素，返回分片编号及添加前该分片是否为空
+func (s *Sharded[T]) Push(v T) (shard int, wasEmpty bool) {
+	shard = int(s.next.Add(1) % uint64(len(s.shards)))
+	return shard, s.shards[shard].Push(v)
+}
+
+// PushTo 按 key 选择分片添加元素，相同 key 始终进入同一分片以保持顺序
+func (s *Sharded[T]) PushTo(key uint64, v T) (shard int, wasEmpty bool) {
+	shard = int(key % uint64(len(s.shards)))
+	return shard, s.shards[shard].Push(v)
+}
+
+// DrainShard 取出并处理指定分片的所有元素
+// 同一分片同一时刻只能有一个消费者调用
+func (s *Sharded[T]) DrainShard(shard int, fn func(T)) int {
+	return s.shards[shard].DrainAll(fn)
+}
+
+// Shard 返回指定分片的队列
+func (s *Sharded[T]) Shard(shard int) *MPSC[T] {
+	return s.shards[shard]
+}
+
+// NumShards 返回分片数量
+func (s *Sharded[T]) NumShards() int {
+	return len(s.shards)
+}
@@ -0,0 +1,95 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// rateLimiter 令牌桶，仅由 run loop 访问
+type rateLimiter struct {
+	rate   float64 // 每秒补充的令牌数
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func (l *rateLimiter) refill(now time.Time) {
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+}
+
+// take 取一个令牌
+func (l *rateLimiter) take(now time.Time) bool {
+	l.refill(now)
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// next 返回下一个令牌可用的时间
+func (l *rateLimiter) next() time.Time {
+	return l.last.Add(time.Duration((1 - l.tokens) / l.rate * float64(time.Second)))
+}
+
+// rateLimit WithRateLimit 的令牌桶与等待令牌的到期任务，仅由 run loop 访问
+type rateLimit struct {
+	limiter  *rateLimiter // nil 表示不限速
+	deferred []*Entry     // 超出速率限制、等待令牌的到期任务
+	head     int
+}
+
+// WithRateLimit 限制每秒交给 handler 的任务数，默认不限
+// 超出速率的到期任务按到期顺序排队，令牌可用时依次触发，防止突发到期压垮下游
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(t *Timer) {
+		if perSecond <= 0 {
+			t.rate.limiter = nil
+			return
+		}
+		burst = max(burst, 1)
+		t.rate.limiter = &rateLimiter{rate: perSecond, burst: float64(burst), tokens: float64(burst)}
+	}
+}
+
+// limited 检查是否需要排队，已有排队任务时新任务也必须排队以保持顺序
+func (t *Timer) limited(entry *Entry) bool {
+	if t.rate.limiter == nil {
+		return false
+	}
+	if len(t.rate.deferred) == t.rate.head && (entry.IsDone() || t.rate.limiter.take(t.now())) {
+		return false
+	}
+	t.rate.deferred = append(t.rate.deferred, entry)
+	return true
+}
+
+// fireDeferred 按顺序触发令牌允许的排队任务
+func (t *Timer) fireDeferred() {
+	if t.rate.limiter == nil || len(t.rate.deferred) == t.rate.head {
+		return
+	}
+	now := t.now()
+	for t.rate.head < len(t.rate.deferred) {
+		entry := t.rate.deferred[t.rate.head]
+		if !entry.IsDone() && !t.rate.limiter.take(now) {
+			return
+		}
+		t.rate.deferred[t.rate.head] = nil
+		t.rate.head++
+		t.fire(entry)
+	}
+	t.rate.deferred = t.rate.deferred[:0]
+	t.rate.head = 0
+}
+
+// nextDeferred 返回排队任务的下次触发时间，没有排队任务时返回 nil
+func (t *Timer) nextDeferred() *time.Time {
+	if t.rate.limiter == nil || len(t.rate.deferred) == t.rate.head {
+		return nil
+	}
+	next := t.rate.limiter.next()
+	return &next
+}
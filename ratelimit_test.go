@@ -0,0 +1,55 @@
+package whTimer
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTimerRateLimit(t *testing.T) {
+	base := time.Now()
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithRateLimit(100, 2))
+	timer.Advance(base)
+
+	var fired []int
+	for i := 0; i < 5; i++ {
+		timer.AddEntry(time.Duration(i+1)*time.Millisecond, func() { fired = append(fired, i) })
+	}
+
+	steps := []struct {
+		at   time.Duration
+		want int
+	}{
+		{10 * time.Millisecond, 2}, // 突发容量 2
+		{20 * time.Millisecond, 3}, // 每 10ms 补充一个令牌
+		{45 * time.Millisecond, 5},
+	}
+	for _, s := range steps {
+		timer.Advance(base.Add(s.at))
+		if len(fired) != s.want {
+			t.Fatalf("at %v fired=%v, want %d", s.at, fired, s.want)
+		}
+	}
+	for i, v := range fired {
+		if v != i {
+			t.Fatalf("fired out of order: %v", fired)
+		}
+	}
+}
+
+func TestTimerRateLimitRunning(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithRateLimit(200, 1))
+	timer.Start()
+	defer timer.Stop()
+
+	var wg sync.WaitGroup
+	wg.Add(5)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		timer.AddEntry(time.Millisecond, wg.Done)
+	}
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Fatalf("5 fires at 200/s took %v, want >= 20ms", elapsed)
+	}
+}
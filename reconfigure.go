@@ -0,0 +1,70 @@
+package whTimer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotReconfigurable Reconfigure 收到只能在创建时设置的配置项
+var ErrNotReconfigurable = errors.New("whTimer: option cannot be changed by Reconfigure")
+
+// Reconfigure 在运行中的定时器上热更新配置，于下一轮 run loop 生效
+// 仅 run loop 使用的配置可热更新：等待策略、关键任务提前量、触发时间记录、延迟告警、触发前检查与挂起恢复相关配置；
+// 其他配置项只在创建时生效，传入任意一个时返回 ErrNotReconfigurable，所有配置项均不生效
+func (t *Timer) Reconfigure(opts ...Option) error {
+	for i, opt := range opts {
+		// 新增的配置项默认不可热更新，只有显式标记的配置项通过检查
+		scratch := &Timer{}
+		opt(scratch)
+		if scratch.hotOption == 0 {
+			return fmt.Errorf("%w (option %d)", ErrNotReconfigurable, i)
+		}
+	}
+
+	t.inspect(func() {
+		cfg := &Timer{
+			wait:           t.wait,
+			criticalLead:   t.criticalLead,
+			fireTimestamps: t.fireTimestamps,
+			hooks:          t.hooks,
+			resume:         t.resume,
+		}
+		for _, opt := range opts {
+			opt(cfg)
+		}
+
+		t.wait = cfg.wait
+		t.criticalLead = cfg.criticalLead
+		t.fireTimestamps = cfg.fireTimestamps
+		t.hooks = cfg.hooks
+		t.resume = cfg.resume
+	})
+
+	// 正在等待的 run loop 立即按新配置重新计算
+	select {
+	case t.wakeChan <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// SetHandler 替换定时器的 handler，于下一轮 run loop 生效 - Wait-Free
+// 可在 handler 内调用，例如关闭前切换为只释放条目的 handler；
+// 生效前已交给旧 handler 的条目不受影响，经 SubTimer 添加的条目仍使用各自的 handler
+func (t *Timer) SetHandler(handler func(*Entry)) {
+	if handler == nil {
+		panic("whTimer: nil handler")
+	}
+	t.nextHandler.Store(&handler)
+	select {
+	case t.wakeChan <- struct{}{}:
+	default:
+	}
+}
+
+// applyHandler 由 run loop 在每轮开始时调用
+func (t *Timer) applyHandler() {
+	if h := t.nextHandler.Swap(nil); h != nil {
+		t.handler = *h
+	}
+}
@@ -0,0 +1,77 @@
+package whTimer
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimerReconfigure(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	if err := timer.Reconfigure(WithWaitStrategy(SpinWait{}), WithCriticalLead(time.Millisecond), WithPooling(false)); !errors.Is(err, ErrNotReconfigurable) {
+		t.Fatalf("startup-only option: err=%v", err)
+	}
+	if err := timer.Reconfigure(WithWaitStrategy(SpinWait{}), WithCriticalLead(time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	for _, opt := range []Option{WithStableOrder(true), WithRateLimit(10, 1), WithBatchHandler(func([]*Entry) {}), WithOnMissedRun(time.Second, nil)} {
+		if err := timer.Reconfigure(opt); !errors.Is(err, ErrNotReconfigurable) {
+			t.Fatalf("startup-only option accepted: err=%v", err)
+		}
+	}
+
+	var wait WaitStrategy
+	var lead time.Duration
+	timer.inspect(func() { wait, lead = timer.wait, timer.criticalLead })
+	if _, ok := wait.(SpinWait); !ok || lead != time.Millisecond {
+		t.Fatalf("wait=%T lead=%v", wait, lead)
+	}
+	if timer.noPooling {
+		t.Fatal("startup-only option applied by Reconfigure")
+	}
+
+	done := make(chan struct{})
+	timer.AddEntry(5*time.Millisecond, func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("entry did not fire after Reconfigure")
+	}
+}
+
+func TestTimerSetHandler(t *testing.T) {
+	var executed, released atomic.Int32
+	var timer *Timer
+	timer = NewTimer(func(e *Entry) {
+		executed.Add(1)
+		e.Execute()
+		// 在 handler 内切换为只释放条目的 handler
+		timer.SetHandler(func(e *Entry) {
+			released.Add(1)
+			e.Release()
+		})
+	})
+	timer.Start()
+	defer timer.Stop()
+
+	first := make(chan struct{})
+	timer.AddEntry(time.Millisecond, func() { close(first) })
+	<-first
+
+	var ran atomic.Bool
+	done := make(chan struct{})
+	timer.AddEntry(time.Millisecond, func() { ran.Store(true) })
+	timer.AddEntry(2*time.Millisecond, func() { close(done) })
+	select {
+	case <-done:
+		t.Fatal("replaced handler should not execute callbacks")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if executed.Load() != 1 || released.Load() != 2 || ran.Load() {
+		t.Fatalf("executed=%d released=%d ran=%v", executed.Load(), released.Load(), ran.Load())
+	}
+}
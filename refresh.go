@@ -0,0 +1,101 @@
+package whTimer
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// RefreshAhead 缓存提前刷新调度器
+// 每个键至多一个待执行的刷新，在 TTL 到期前按比例提前触发，并加入随机抖动，
+// 避免同时写入的大量键在同一时刻回源
+type RefreshAhead struct {
+	timer   *Timer
+	factor  float64
+	jitter  float64
+	refresh func(key string)
+
+	mu      sync.Mutex
+	pending map[string]EntryRef
+}
+
+// NewRefreshAhead 创建提前刷新调度器
+// 刷新时间为 TTL×factor 再随机提前至多 TTL×jitter，例如 factor=0.8、jitter=0.1
+// 表示在 TTL 的 70%~80% 之间刷新；factor 不在 (0, 1] 内时按 0.8 处理
+func (t *Timer) NewRefreshAhead(factor, jitter float64, refresh func(key string)) *RefreshAhead {
+	if factor <= 0 || factor > 1 {
+		factor = 0.8
+	}
+	return &RefreshAhead{
+		timer:   t,
+		factor:  factor,
+		jitter:  max(jitter, 0),
+		refresh: refresh,
+		pending: make(map[string]EntryRef),
+	}
+}
+
+// Schedule 为 key 安排刷新，已有待执行的刷新时替换为按新 TTL 计算的时间
+func (r *RefreshAhead) Schedule(key string, ttl time.Duration) {
+	delay := time.Duration(float64(ttl) * r.factor)
+	if j := int64(float64(ttl) * r.jitter); j > 0 {
+		delay -= time.Duration(rand.Int64N(j))
+	}
+	delay = max(delay, 0)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.pending[key]; ok {
+		old.Cancel()
+	}
+
+	var ref EntryRef
+	_, ref = r.timer.addEntryRef(r.timer.now().Add(delay), func() {
+		r.mu.Lock()
+		if r.pending[key] != ref {
+			r.mu.Unlock()
+			return
+		}
+		delete(r.pending, key)
+		r.mu.Unlock()
+		r.refresh(key)
+	})
+	r.pending[key] = ref
+}
+
+// Cancel 取消 key 待执行的刷新，返回是否存在
+func (r *RefreshAhead) Cancel(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ref, ok := r.pending[key]
+	if ok {
+		ref.Cancel()
+		delete(r.pending, key)
+	}
+	return ok
+}
+
+// Pending 检查 key 是否有待执行的刷新
+func (r *RefreshAhead) Pending(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.pending[key]
+	return ok
+}
+
+// Len 返回待执行的刷新数量
+func (r *RefreshAhead) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.pending)
+}
+
+// Stop 取消所有待执行的刷新
+func (r *RefreshAhead) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, ref := range r.pending {
+		ref.Cancel()
+		delete(r.pending, key)
+	}
+}
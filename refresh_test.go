@@ -0,0 +1,38 @@
+package whTimer
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRefreshAhead(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	var refreshed []string
+	r := timer.NewRefreshAhead(0.8, 0.1, func(key string) { refreshed = append(refreshed, key) })
+	r.Schedule("a", 10*time.Second)
+	r.Schedule("b", 10*time.Second)
+	// 重复安排只保留最新的一次
+	r.Schedule("a", 100*time.Second)
+	if r.Len() != 2 {
+		t.Fatalf("len=%d", r.Len())
+	}
+
+	timer.Advance(base.Add(6900 * time.Millisecond))
+	if len(refreshed) != 0 {
+		t.Fatalf("refreshed too early: %v", refreshed)
+	}
+	timer.Advance(base.Add(8 * time.Second))
+	if !slices.Equal(refreshed, []string{"b"}) || r.Pending("b") || !r.Pending("a") {
+		t.Fatalf("refreshed=%v", refreshed)
+	}
+
+	r.Cancel("a")
+	timer.Advance(base.Add(100 * time.Second))
+	if len(refreshed) != 1 || r.Len() != 0 {
+		t.Fatalf("canceled refresh ran: %v", refreshed)
+	}
+}
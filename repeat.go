@@ -0,0 +1,41 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// AddRepeating 添加重复执行的任务：delay 后首次执行，之后每隔 interval 执行，共 n 次 - Wait-Free
+// n <= 0 时重复到被取消为止。重复状态保存在条目自身，每次重复无需新的条目或闭包；
+// 返回的条目在全部执行完成前保持有效，Cancel 在两次执行之间生效
+// 重复依赖 handler 调用 Execute，未调用 Execute 的 handler 只会触发一次
+func (t *Timer) AddRepeating(delay, interval time.Duration, n int, fn func()) *Entry {
+	entry := t.newEntry(t.now().Add(delay), fn)
+	entry.delay = delay
+	entry.interval = max(interval, time.Millisecond)
+	entry.repeats = int64(n - 1)
+	if n <= 0 {
+		entry.repeats = -1
+	}
+	return t.push(entry)
+}
+
+// rearm 执行完成后重新放入所属定时器，返回是否已放入
+// 条目执行期间被 Release 时不再重复
+func (e *Entry) rearm() bool {
+	for {
+		v := e.state.Load()
+		if EntryState(v&stateMask) != StateFired || v&flagReleased != 0 {
+			return false
+		}
+		if e.state.CompareAndSwap(v, v&^(stateMask|flagDetached|flagPushed|flagSealed)|uint64(StateQueued)) {
+			break
+		}
+	}
+	if e.repeats > 0 {
+		e.repeats--
+	}
+	e.expireAt = e.expireAt.Add(e.interval)
+	e.delay = e.interval
+	e.owner.push(e)
+	return true
+}
@@ -0,0 +1,46 @@
+package whTimer
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimerAddRepeating(t *testing.T) {
+	var lateness []time.Duration
+	timer := NewTimer(func(e *Entry) {
+		lateness = append(lateness, e.Lateness())
+		e.Execute()
+	}, WithFireTimestamps(true))
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	var runs int
+	e := timer.AddRepeating(100*time.Millisecond, 50*time.Millisecond, 3, func() { runs++ })
+	ref := e.Ref()
+	timer.Advance(base.Add(120 * time.Millisecond))
+	if runs != 1 || ref.State() != StateScheduled {
+		t.Fatalf("runs=%d state=%v", runs, ref.State())
+	}
+	timer.Advance(base.Add(time.Second))
+	if runs != 3 || ref.State() != StateFired {
+		t.Fatalf("runs=%d state=%v", runs, ref.State())
+	}
+	// 第 2、3 次按 150ms、200ms 的计划时间计算延迟
+	if !slices.Equal(lateness, []time.Duration{20 * time.Millisecond, 850 * time.Millisecond, 800 * time.Millisecond}) {
+		t.Fatalf("lateness=%v", lateness)
+	}
+
+	var forever int
+	f := timer.AddRepeating(0, time.Second, 0, func() { forever++ })
+	for i := 1; i <= 5; i++ {
+		timer.Advance(base.Add(time.Duration(i) * time.Second))
+	}
+	if !f.Cancel() {
+		t.Fatal("cancel between repetitions failed")
+	}
+	timer.Advance(base.Add(time.Minute))
+	if forever != 5 {
+		t.Fatalf("forever=%d, want 5", forever)
+	}
+}
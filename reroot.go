@@ -0,0 +1,93 @@
+package whTimer
+
+import (
+	"math/bits"
+	"time"
+)
+
+// WithAutoReroot run loop 至多每 interval 检查一次时间轮能否降到更低层级，默认关闭
+// 根轮只在 0 号槽之外全部清空时降级，短暂出现又被取消的长延迟任务会让时间轮保持较高层级直到其到期；
+// 检查只遍历根轮 0 号槽之外的分支
+func WithAutoReroot(interval time.Duration) Option {
+	return func(t *Timer) {
+		t.housekeeping.rerootEvery = interval
+	}
+}
+
+// Reroot 移除根轮 0 号槽之外只剩已取消条目的分支，使时间轮降到能容纳剩余条目的最低层级
+// 返回回收的已取消条目数，被回收的条目不再交给 handler；在 handler 中调用时不执行，返回 0
+func (t *Timer) Reroot() int {
+	var n int
+	t.inspect(func() {
+		if t.idle() {
+			n = t.reroot()
+		}
+	})
+	return n
+}
+
+func (t *Timer) reroot() int {
+	total := 0
+	for {
+		n, ok := t.trimRoot()
+		total += n
+		if !ok {
+			return total
+		}
+	}
+}
+
+// trimRoot 根轮 0 号槽之外只有已取消条目时移除它们并降级，返回移除数量与是否已降级
+func (t *Timer) trimRoot() (int, bool) {
+	w := t.wheel
+	if w == nil || w.level == 0 || w.bitmap <= 1 {
+		return 0, false
+	}
+
+	type resident struct {
+		entry *Entry
+		ms    uint64
+	}
+	var canceled []resident
+	live := false
+	for bitmap := w.bitmap &^ 1; bitmap != 0 && !live; bitmap &= bitmap - 1 {
+		index := uint64(bits.TrailingZeros64(bitmap))
+		w.subWheels[index].walk(index*msPerSlot[w.level], func(e *Entry, ms uint64) bool {
+			if !e.IsCanceled() {
+				live = true
+				return false
+			}
+			canceled = append(canceled, resident{e, ms})
+			return true
+		})
+	}
+	if live {
+		return 0, false
+	}
+
+	for _, r := range canceled {
+		w.RemoveEntry(r.entry, r.ms)
+		t.numEntries--
+		t.release(r.entry)
+	}
+	if w.Empty() {
+		t.wheel = nil
+		t.numEntries = 0
+		return len(canceled), false
+	}
+	t.levelDownIfNeeded()
+	return len(canceled), true
+}
+
+// maybeReroot 由 maintenance 调用，按 WithAutoReroot 的配置检查并降级
+func (t *Timer) maybeReroot() {
+	if t.housekeeping.rerootEvery <= 0 {
+		return
+	}
+	now := t.now()
+	if now.Before(t.housekeeping.nextReroot) {
+		return
+	}
+	t.housekeeping.nextReroot = now.Add(t.housekeeping.rerootEvery)
+	t.reroot()
+}
@@ -0,0 +1,61 @@
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerReroot(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	var fired int
+	for i := 1; i <= 5; i++ {
+		timer.AddEntry(time.Duration(i*10)*time.Millisecond, func() { fired++ })
+	}
+	long := timer.AddEntry(time.Hour, func() { fired++ })
+	timer.Advance(base)
+	if level := timer.CurrentLevel(); level < 3 {
+		t.Fatalf("level = %d, want the long entry to raise the wheel", level)
+	}
+
+	// 长延迟任务被取消后仍驻留，根轮无法自行降级
+	long.Cancel()
+	timer.Advance(base.Add(time.Millisecond))
+	if level := timer.CurrentLevel(); level < 3 {
+		t.Fatalf("level = %d before reroot", level)
+	}
+	if n := timer.Reroot(); n != 1 {
+		t.Fatalf("Reroot removed %d entries, want 1", n)
+	}
+	if level := timer.CurrentLevel(); level != 0 {
+		t.Fatalf("level = %d after reroot, want 0", level)
+	}
+	if err := timer.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	timer.Advance(base.Add(50 * time.Millisecond))
+	if fired != 5 {
+		t.Fatalf("fired %d, want 5", fired)
+	}
+
+	// 存在未取消的远端任务时保持原层级
+	timer.AddEntry(10*time.Millisecond, func() {})
+	timer.AddEntry(time.Hour, func() {})
+	timer.Advance(base.Add(51 * time.Millisecond))
+	level := timer.CurrentLevel()
+	if n := timer.Reroot(); n != 0 || timer.CurrentLevel() != level {
+		t.Fatalf("Reroot removed %d live entries, level %d -> %d", n, level, timer.CurrentLevel())
+	}
+
+	// 自动检查
+	timer = NewTimer(func(e *Entry) { e.Execute() }, WithAutoReroot(10*time.Millisecond))
+	timer.Advance(base)
+	timer.AddEntry(time.Minute, func() {})
+	timer.AddEntry(time.Hour, func() {}).Cancel()
+	timer.Advance(base.Add(20 * time.Millisecond))
+	if level := timer.CurrentLevel(); level != 2 {
+		t.Fatalf("level = %d with auto reroot, want 2", level)
+	}
+}
@@ -0,0 +1,41 @@
+package whTimer
+
+// routeTable 标签到 handler 的路由表，写时复制
+type routeTable map[string]func(*Entry)
+
+// Handle 为标签为 tag 的条目注册专用 handler，未注册的标签使用默认 handler
+// 可在运行中调用，handler 为 nil 时移除该路由；经 SubTimer 添加的条目仍使用各自的 handler
+func (t *Timer) Handle(tag string, handler func(*Entry)) {
+	t.routesMu.Lock()
+	defer t.routesMu.Unlock()
+
+	routes := make(routeTable)
+	if old := t.routes.Load(); old != nil {
+		for k, v := range *old {
+			routes[k] = v
+		}
+	}
+	if handler == nil {
+		delete(routes, tag)
+	} else {
+		routes[tag] = handler
+	}
+	if len(routes) == 0 {
+		t.routes.Store(nil)
+		return
+	}
+	t.routes.Store(&routes)
+}
+
+// route 返回条目应交给的 handler
+func (t *Timer) route(entry *Entry) func(*Entry) {
+	if entry.handler != nil {
+		return entry.handler
+	}
+	if routes := t.routes.Load(); routes != nil {
+		if h, ok := (*routes)[entry.tag]; ok {
+			return h
+		}
+	}
+	return t.handler
+}
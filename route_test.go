@@ -0,0 +1,36 @@
+package whTimer
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimerHandle(t *testing.T) {
+	var got []string
+	handler := func(name string) func(*Entry) {
+		return func(e *Entry) {
+			got = append(got, name+":"+e.Tag())
+			e.Execute()
+		}
+	}
+	timer := NewTimer(handler("default"))
+	timer.Handle("email", handler("email"))
+	timer.Handle("cleanup", handler("cleanup"))
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	timer.AddTaggedEntry("email", time.Millisecond, func() {})
+	timer.AddTaggedEntry("cleanup", 2*time.Millisecond, func() {})
+	timer.AddTaggedEntry("other", 3*time.Millisecond, func() {})
+	timer.Advance(base.Add(time.Second))
+
+	timer.Handle("email", nil)
+	timer.AddTaggedEntry("email", time.Millisecond, func() {})
+	timer.Advance(base.Add(2 * time.Second))
+
+	want := []string{"email:email", "cleanup:cleanup", "default:other", "default:email"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,324 @@
+package whTimer
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rruleFreq RRULE 重复频率
+type rruleFreq int
+
+const (
+	freqSecondly rruleFreq = iota
+	freqMinutely
+	freqHourly
+	freqDaily
+	freqWeekly
+	freqMonthly
+	freqYearly
+)
+
+var rruleFreqs = map[string]rruleFreq{
+	"SECONDLY": freqSecondly,
+	"MINUTELY": freqMinutely,
+	"HOURLY":   freqHourly,
+	"DAILY":    freqDaily,
+	"WEEKLY":   freqWeekly,
+	"MONTHLY":  freqMonthly,
+	"YEARLY":   freqYearly,
+}
+
+var rruleDays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// rruleDay BYDAY 条目，n 为月内序号（如 -1FR 表示最后一个周五），0 表示每个
+type rruleDay struct {
+	n       int
+	weekday time.Weekday
+}
+
+// rruleMaxPeriods 单次 Next 最多检查的周期数，防止不可能满足的规则死循环
+const rruleMaxPeriods = 100000
+
+// rruleSchedule RFC 5545 RRULE 调度规则
+type rruleSchedule struct {
+	dtstart  time.Time
+	freq     rruleFreq
+	interval int
+	byDay    []rruleDay
+	until    time.Time
+	count    int
+}
+
+// ParseRRule 解析 RFC 5545 RRULE，dtstart 为首次发生时间，同时决定时区与每次发生的时刻
+// 支持 FREQ、INTERVAL、BYDAY、UNTIL、COUNT；BYDAY 序号（如 1MO、-1FR）仅用于 MONTHLY
+func ParseRRule(rule string, dtstart time.Time) (Schedule, error) {
+	s := &rruleSchedule{dtstart: dtstart, freq: -1, interval: 1}
+
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("whTimer: invalid RRULE part %q", part)
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			freq, ok := rruleFreqs[strings.ToUpper(value)]
+			if !ok {
+				return nil, fmt.Errorf("whTimer: invalid RRULE FREQ %q", value)
+			}
+			s.freq = freq
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("whTimer: invalid RRULE INTERVAL %q", value)
+			}
+			s.interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("whTimer: invalid RRULE COUNT %q", value)
+			}
+			s.count = n
+		case "UNTIL":
+			until, err := parseRRuleTime(value, dtstart.Location())
+			if err != nil {
+				return nil, err
+			}
+			s.until = until
+		case "BYDAY":
+			for _, item := range strings.Split(value, ",") {
+				day, err := parseRRuleDay(item)
+				if err != nil {
+					return nil, err
+				}
+				s.byDay = append(s.byDay, day)
+			}
+		default:
+			return nil, fmt.Errorf("whTimer: unsupported RRULE part %q", key)
+		}
+	}
+
+	if s.freq < 0 {
+		return nil, fmt.Errorf("whTimer: RRULE %q missing FREQ", rule)
+	}
+	if s.count > 0 && !s.until.IsZero() {
+		return nil, fmt.Errorf("whTimer: RRULE %q has both COUNT and UNTIL", rule)
+	}
+	for _, day := range s.byDay {
+		if day.n != 0 && s.freq != freqMonthly {
+			return nil, fmt.Errorf("whTimer: RRULE BYDAY ordinal only supported with FREQ=MONTHLY")
+		}
+	}
+	if len(s.byDay) > 0 && s.freq == freqYearly {
+		return nil, fmt.Errorf("whTimer: RRULE BYDAY not supported with FREQ=YEARLY")
+	}
+	return s, nil
+}
+
+// RRule 按 RFC 5545 RRULE 创建周期任务
+func (t *Timer) RRule(rule string, dtstart time.Time, callback func()) (*CronEntry, error) {
+	s, err := ParseRRule(rule, dtstart)
+	if err != nil {
+		return nil, err
+	}
+	return t.AddSchedule(s, callback), nil
+}
+
+func parseRRuleTime(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			if strings.HasSuffix(value, "Z") {
+				t, _ = time.Parse(layout, value)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("whTimer: invalid RRULE UNTIL %q", value)
+}
+
+func parseRRuleDay(item string) (rruleDay, error) {
+	if len(item) < 2 {
+		return rruleDay{}, fmt.Errorf("whTimer: invalid RRULE BYDAY %q", item)
+	}
+	weekday, ok := rruleDays[strings.ToUpper(item[len(item)-2:])]
+	if !ok {
+		return rruleDay{}, fmt.Errorf("whTimer: invalid RRULE BYDAY %q", item)
+	}
+	day := rruleDay{weekday: weekday}
+	if prefix := item[:len(item)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return rruleDay{}, fmt.Errorf("whTimer: invalid RRULE BYDAY %q", item)
+		}
+		day.n = n
+	}
+	return day, nil
+}
+
+// Next 返回 t 之后的下一次发生时间，超出 COUNT 或 UNTIL 后返回零值
+func (s *rruleSchedule) Next(t time.Time) time.Time {
+	// 无 COUNT 时可直接跳到 t 附近的周期，否则需从头计数
+	k := 0
+	if s.count == 0 {
+		k = max(s.periodsUntil(t)-1, 0)
+	}
+
+	seen := 0
+	for end := k + rruleMaxPeriods; k < end; k++ {
+		for _, c := range s.period(k) {
+			if c.Before(s.dtstart) {
+				continue
+			}
+			seen++
+			if s.count > 0 && seen > s.count {
+				return time.Time{}
+			}
+			if !s.until.IsZero() && c.After(s.until) {
+				return time.Time{}
+			}
+			if c.After(t) {
+				return c
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// periodsUntil 估算 dtstart 到 t 之间经过的周期数
+func (s *rruleSchedule) periodsUntil(t time.Time) int {
+	if !t.After(s.dtstart) {
+		return 0
+	}
+
+	var units int
+	switch s.freq {
+	case freqSecondly:
+		units = int(t.Sub(s.dtstart) / time.Second)
+	case freqMinutely:
+		units = int(t.Sub(s.dtstart) / time.Minute)
+	case freqHourly:
+		units = int(t.Sub(s.dtstart) / time.Hour)
+	case freqDaily:
+		units = daysBetween(s.dtstart, t)
+	case freqWeekly:
+		units = daysBetween(s.dtstart, t) / 7
+	case freqMonthly:
+		ty, tm, _ := t.In(s.dtstart.Location()).Date()
+		sy, sm, _ := s.dtstart.Date()
+		units = (ty-sy)*12 + int(tm-sm)
+	case freqYearly:
+		units = t.In(s.dtstart.Location()).Year() - s.dtstart.Year()
+	}
+	return units / s.interval
+}
+
+// daysBetween 返回 dtstart 时区下 from 到 to 的日历天数
+func daysBetween(from, to time.Time) int {
+	fy, fm, fd := from.Date()
+	ty, tm, td := to.In(from.Location()).Date()
+	a := time.Date(fy, fm, fd, 0, 0, 0, 0, time.UTC)
+	b := time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a) / (24 * time.Hour))
+}
+
+// period 返回第 k 个周期内按时间排序的候选发生时间
+func (s *rruleSchedule) period(k int) []time.Time {
+	d := s.dtstart
+	loc := d.Location()
+	year, month, day := d.Date()
+	hour, min, sec := d.Clock()
+	nsec := d.Nanosecond()
+	step := k * s.interval
+
+	at := func(y int, m time.Month, dd int) time.Time {
+		return time.Date(y, m, dd, hour, min, sec, nsec, loc)
+	}
+
+	var result []time.Time
+	switch s.freq {
+	case freqSecondly, freqMinutely, freqHourly, freqDaily:
+		var c time.Time
+		switch s.freq {
+		case freqSecondly:
+			c = d.Add(time.Duration(step) * time.Second)
+		case freqMinutely:
+			c = d.Add(time.Duration(step) * time.Minute)
+		case freqHourly:
+			c = d.Add(time.Duration(step) * time.Hour)
+		default:
+			c = at(year, month, day+step)
+		}
+		if s.matchDay(c.In(loc).Weekday()) {
+			result = append(result, c)
+		}
+	case freqWeekly:
+		// 周从周一开始（WKST=MO）
+		monday := day - (int(d.Weekday())+6)%7 + 7*step
+		if len(s.byDay) == 0 {
+			result = append(result, at(year, month, day+7*step))
+			break
+		}
+		for _, bd := range s.byDay {
+			result = append(result, at(year, month, monday+(int(bd.weekday)+6)%7))
+		}
+	case freqMonthly:
+		first := time.Date(year, month+time.Month(step), 1, 0, 0, 0, 0, loc)
+		y, m := first.Year(), first.Month()
+		days := time.Date(y, m+1, 0, 0, 0, 0, 0, loc).Day()
+		if len(s.byDay) == 0 {
+			// 没有该日期的月份跳过（如 31 号）
+			if day <= days {
+				result = append(result, at(y, m, day))
+			}
+			break
+		}
+		for _, bd := range s.byDay {
+			offset := (int(bd.weekday) - int(first.Weekday()) + 7) % 7
+			var matches []int
+			for dd := 1 + offset; dd <= days; dd += 7 {
+				matches = append(matches, dd)
+			}
+			switch {
+			case bd.n == 0:
+				for _, dd := range matches {
+					result = append(result, at(y, m, dd))
+				}
+			case bd.n > 0 && bd.n <= len(matches):
+				result = append(result, at(y, m, matches[bd.n-1]))
+			case bd.n < 0 && -bd.n <= len(matches):
+				result = append(result, at(y, m, matches[len(matches)+bd.n]))
+			}
+		}
+	case freqYearly:
+		// 闰日在非闰年跳过
+		if c := at(year+step, month, day); c.Day() == day {
+			result = append(result, c)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b time.Time) int { return a.Compare(b) })
+	return slices.CompactFunc(result, time.Time.Equal)
+}
+
+func (s *rruleSchedule) matchDay(w time.Weekday) bool {
+	if len(s.byDay) == 0 {
+		return true
+	}
+	for _, bd := range s.byDay {
+		if bd.weekday == w {
+			return true
+		}
+	}
+	return false
+}
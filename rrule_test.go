@@ -0,0 +1,65 @@
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func collect(s Schedule, from time.Time, n int) []time.Time {
+	var result []time.Time
+	for t := from; len(result) < n; {
+		t = s.Next(t)
+		if t.IsZero() {
+			break
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+func TestRRule(t *testing.T) {
+	date := func(y int, m time.Month, d, h int) time.Time {
+		return time.Date(y, m, d, h, 0, 0, 0, time.UTC)
+	}
+	dtstart := date(2025, 1, 6, 9) // 周一
+
+	cases := []struct {
+		rule string
+		want []time.Time
+	}{
+		{"FREQ=DAILY;COUNT=3", []time.Time{date(2025, 1, 6, 9), date(2025, 1, 7, 9), date(2025, 1, 8, 9)}},
+		{"RRULE:FREQ=WEEKLY;BYDAY=MO,FR;COUNT=4", []time.Time{date(2025, 1, 6, 9), date(2025, 1, 10, 9), date(2025, 1, 13, 9), date(2025, 1, 17, 9)}},
+		{"FREQ=WEEKLY;INTERVAL=2;UNTIL=20250201T000000Z", []time.Time{date(2025, 1, 6, 9), date(2025, 1, 20, 9)}},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", []time.Time{date(2025, 1, 31, 9), date(2025, 2, 28, 9), date(2025, 3, 28, 9)}},
+		{"FREQ=MONTHLY;BYDAY=1MO;COUNT=2", []time.Time{date(2025, 1, 6, 9), date(2025, 2, 3, 9)}},
+		{"FREQ=DAILY;BYDAY=SA,SU;COUNT=2", []time.Time{date(2025, 1, 11, 9), date(2025, 1, 12, 9)}},
+		{"FREQ=HOURLY;INTERVAL=6;COUNT=3", []time.Time{date(2025, 1, 6, 9), date(2025, 1, 6, 15), date(2025, 1, 6, 21)}},
+	}
+	for _, c := range cases {
+		s, err := ParseRRule(c.rule, dtstart)
+		if err != nil {
+			t.Fatalf("%s: %v", c.rule, err)
+		}
+		got := collect(s, dtstart.Add(-time.Second), 10)
+		if len(got) != len(c.want) {
+			t.Fatalf("%s: got %v, want %v", c.rule, got, c.want)
+		}
+		for i := range got {
+			if !got[i].Equal(c.want[i]) {
+				t.Fatalf("%s: got %v, want %v", c.rule, got, c.want)
+			}
+		}
+	}
+
+	// 无 COUNT 时跳到远期周期
+	s, _ := ParseRRule("FREQ=MONTHLY", date(2020, 1, 31, 9))
+	if next := s.Next(date(2025, 3, 1, 0)); !next.Equal(date(2025, 3, 31, 9)) {
+		t.Fatalf("monthly skip: %v", next)
+	}
+
+	for _, rule := range []string{"", "COUNT=3", "FREQ=FOO", "FREQ=DAILY;BYHOUR=9", "FREQ=WEEKLY;BYDAY=1MO", "FREQ=DAILY;COUNT=2;UNTIL=20250101"} {
+		if _, err := ParseRRule(rule, dtstart); err == nil {
+			t.Errorf("ParseRRule(%q) accepted", rule)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// Runner 由一个 run loop 驱动的多个逻辑定时器
+// 各库可以拥有独立 handler 与命名空间的"自己的定时器"，而无需各自启动后台 goroutine
+type Runner struct {
+	timer *Timer
+}
+
+// SubTimer Runner 上的逻辑定时器，条目以其名称作为标签
+type SubTimer struct {
+	runner  *Runner
+	name    string
+	handler func(*Entry)
+}
+
+// NewRunner 创建共享 run loop，opts 作用于底层 Timer
+func NewRunner(opts ...Option) *Runner {
+	return &Runner{
+		timer: NewTimer(func(e *Entry) { e.Execute() }, opts...),
+	}
+}
+
+// Start 启动共享 run loop
+func (r *Runner) Start() {
+	r.timer.Start()
+}
+
+// Stop 停止共享 run loop，所有逻辑定时器随之停止
+func (r *Runner) Stop() {
+	r.timer.Stop()
+}
+
+// Timer 返回底层 Timer，可用于 Pending、Upcoming 等整体查询
+func (r *Runner) Timer() *Timer {
+	return r.timer
+}
+
+// NewTimer 创建逻辑定时器，handler 为 nil 时直接执行回调
+func (r *Runner) NewTimer(name string, handler func(*Entry)) *SubTimer {
+	if handler == nil {
+		handler = func(e *Entry) { e.Execute() }
+	}
+	return &SubTimer{
+		runner:  r,
+		name:    name,
+		handler: handler,
+	}
+}
+
+// Name 获取命名空间
+func (s *SubTimer) Name() string {
+	return s.name
+}
+
+// AddEntry 添加定时任务 - Wait-Free
+func (s *SubTimer) AddEntry(delay time.Duration, callback func()) *Entry {
+	t := s.runner.timer
+	return s.push(t.newEntry(t.now().Add(delay), callback), delay)
+}
+
+// AddEntryAt 在指定时间添加定时任务 - Wait-Free
+func (s *SubTimer) AddEntryAt(expireAt time.Time, callback func()) *Entry {
+	t := s.runner.timer
+	return s.push(t.newEntry(expireAt, callback), expireAt.Sub(t.now()))
+}
+
+// AddRunnable 添加 Runnable 定时任务 - Wait-Free
+func (s *SubTimer) AddRunnable(delay time.Duration, r Runnable) *Entry {
+	t := s.runner.timer
+	entry := t.newEntry(t.now().Add(delay), nil)
+	entry.runnable = r
+	return s.push(entry, delay)
+}
+
+// AddRunnableAt 在指定时间添加 Runnable 定时任务 - Wait-Free
+func (s *SubTimer) AddRunnableAt(expireAt time.Time, r Runnable) *Entry {
+	t := s.runner.timer
+	entry := t.newEntry(expireAt, nil)
+	entry.runnable = r
+	return s.push(entry, expireAt.Sub(t.now()))
+}
+
+func (s *SubTimer) push(entry *Entry, delay time.Duration) *Entry {
+	entry.delay = delay
+	entry.tag = s.name
+	entry.handler = s.handler
+	return s.runner.timer.push(entry)
+}
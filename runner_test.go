@@ -0,0 +1,39 @@
+package whTimer
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunner(t *testing.T) {
+	runner := NewRunner()
+	runner.Start()
+	defer runner.Stop()
+
+	var a, b atomic.Int32
+	var wg sync.WaitGroup
+	ta := runner.NewTimer("a", func(e *Entry) {
+		a.Add(1)
+		e.Execute()
+	})
+	tb := runner.NewTimer("b", func(e *Entry) {
+		if e.Tag() != "b" {
+			t.Errorf("tag=%q, want b", e.Tag())
+		}
+		b.Add(1)
+		e.Execute()
+	})
+
+	wg.Add(4)
+	for i := 0; i < 3; i++ {
+		ta.AddEntry(10*time.Millisecond, wg.Done)
+	}
+	tb.AddEntry(20*time.Millisecond, wg.Done)
+	wg.Wait()
+
+	if a.Load() != 3 || b.Load() != 1 {
+		t.Fatalf("a=%d b=%d", a.Load(), b.Load())
+	}
+}
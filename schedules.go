@@ -0,0 +1,263 @@
+//go:build !whtimer_tiny && !tinygo
+
+package whTimer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// JobSpec 配置文件中的任务定义，cron 与 interval 二选一
+type JobSpec struct {
+	Name     string   `json:"name" yaml:"name"`
+	Cron     string   `json:"cron,omitempty" yaml:"cron,omitempty"`         // 秒级 Cron 表达式
+	Interval string   `json:"interval,omitempty" yaml:"interval,omitempty"` // time.ParseDuration 格式，如 "5m"
+	Timezone string   `json:"timezone,omitempty" yaml:"timezone,omitempty"` // Cron 所用时区，默认本地时区
+	CatchUp  string   `json:"catch_up,omitempty" yaml:"catch_up,omitempty"` // interval 停顿补偿策略: once / all / skip
+	Handler  string   `json:"handler" yaml:"handler"`
+	Args     []string `json:"args,omitempty" yaml:"args,omitempty"`
+}
+
+// JobSet 由配置驱动的任务集合，支持热重载
+// 配置为 JSON 数组或同结构的 YAML 列表，按首个非空白字符是否为 '[' 区分
+// 任务触发时按名称查找处理函数，重新 Register 对已加载的任务立即生效
+type JobSet struct {
+	timer *Timer
+
+	mu       sync.Mutex
+	handlers map[string]func(args []string)
+	jobs     map[string]*configJob
+}
+
+type configJob struct {
+	spec  JobSpec
+	entry *CronEntry
+}
+
+// NewJobSet 创建配置驱动的任务集合
+func (t *Timer) NewJobSet() *JobSet {
+	return &JobSet{
+		timer:    t,
+		handlers: make(map[string]func(args []string)),
+		jobs:     make(map[string]*configJob),
+	}
+}
+
+// Register 注册处理函数，args 为任务定义中的参数，同名时替换
+func (j *JobSet) Register(name string, fn func(args []string)) {
+	j.mu.Lock()
+	j.handlers[name] = fn
+	j.mu.Unlock()
+}
+
+// LoadSchedules 加载任务定义并添加到集合，与已有任务重名时返回错误且不做任何修改
+func (j *JobSet) LoadSchedules(r io.Reader) error {
+	specs, err := decodeJobSpecs(r)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, spec := range specs {
+		if _, ok := j.jobs[spec.Name]; ok {
+			return fmt.Errorf("whTimer: job %q already loaded", spec.Name)
+		}
+	}
+	return j.apply(specs, false)
+}
+
+// ReloadSchedules 以 r 中的任务定义为完整期望状态：
+// 新增的任务被添加，删除的任务被停止，定义变化的任务被替换，未变化的任务保持运行不受影响
+// 任一定义无效时返回错误且不做任何修改
+func (j *JobSet) ReloadSchedules(r io.Reader) error {
+	specs, err := decodeJobSpecs(r)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.apply(specs, true)
+}
+
+// Names 返回当前任务名称，按字典序排列
+func (j *JobSet) Names() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	names := make([]string, 0, len(j.jobs))
+	for name := range j.jobs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Stop 停止全部任务
+func (j *JobSet) Stop() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for name, job := range j.jobs {
+		job.entry.Stop()
+		delete(j.jobs, name)
+	}
+}
+
+func decodeJobSpecs(r io.Reader) ([]JobSpec, error) {
+	var specs []JobSpec
+	br := bufio.NewReader(r)
+	var err error
+	if isJSONArray(br) {
+		err = json.NewDecoder(br).Decode(&specs)
+	} else {
+		dec := yaml.NewDecoder(br)
+		dec.KnownFields(true)
+		if err = dec.Decode(&specs); errors.Is(err, io.EOF) {
+			err = errors.New("empty document")
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("whTimer: invalid job definitions: %w", err)
+	}
+
+	seen := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		if spec.Name == "" {
+			return nil, errors.New("whTimer: job without name")
+		}
+		if seen[spec.Name] {
+			return nil, fmt.Errorf("whTimer: duplicate job %q", spec.Name)
+		}
+		seen[spec.Name] = true
+	}
+	return specs, nil
+}
+
+// isJSONArray 检查首个非空白字符是否为 '['
+func isJSONArray(br *bufio.Reader) bool {
+	for n := 1; ; n++ {
+		b, err := br.Peek(n)
+		if err != nil {
+			return false
+		}
+		switch c := b[n-1]; c {
+		case ' ', '\t', '\r', '\n':
+		default:
+			return c == '['
+		}
+	}
+}
+
+// apply 先校验全部定义再修改，保证失败时集合保持原状
+func (j *JobSet) apply(specs []JobSpec, replace bool) error {
+	type start struct {
+		spec     JobSpec
+		expr     string
+		schedule Schedule
+		interval time.Duration
+		policy   CatchUpPolicy
+	}
+
+	var starts []start
+	wanted := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		wanted[spec.Name] = true
+		if job, ok := j.jobs[spec.Name]; ok && sameSpec(job.spec, spec) {
+			continue
+		}
+
+		s := start{spec: spec}
+		if _, ok := j.handlers[spec.Handler]; !ok {
+			return fmt.Errorf("whTimer: job %q: unknown handler %q", spec.Name, spec.Handler)
+		}
+		var err error
+		switch {
+		case spec.Cron != "" && spec.Interval != "":
+			err = errors.New("cron and interval are mutually exclusive")
+		case spec.Cron != "":
+			expr := spec.Cron
+			if spec.Timezone != "" {
+				expr = "CRON_TZ=" + spec.Timezone + " " + expr
+			}
+			s.expr = expr
+			s.schedule, err = ParseCron(expr)
+		case spec.Interval != "":
+			s.interval, err = time.ParseDuration(spec.Interval)
+			if err == nil && s.interval <= 0 {
+				err = fmt.Errorf("interval %v must be positive", s.interval)
+			}
+			if err == nil {
+				s.policy, err = parseCatchUp(spec.CatchUp)
+			}
+		default:
+			err = errors.New("missing cron or interval")
+		}
+		if err != nil {
+			return fmt.Errorf("whTimer: job %q: %w", spec.Name, err)
+		}
+		starts = append(starts, s)
+	}
+
+	if replace {
+		for name, job := range j.jobs {
+			if !wanted[name] {
+				job.entry.Stop()
+				delete(j.jobs, name)
+			}
+		}
+	}
+	for _, s := range starts {
+		if old, ok := j.jobs[s.spec.Name]; ok {
+			old.entry.Stop()
+		}
+		callback := j.runner(s.spec.Handler, s.spec.Args)
+
+		job := &configJob{spec: s.spec}
+		if s.schedule != nil {
+			job.entry = j.timer.addSchedule(s.expr, s.schedule, callback)
+		} else {
+			job.entry = j.timer.CronIntervalPolicy(s.interval, s.policy, callback)
+		}
+		j.jobs[s.spec.Name] = job
+	}
+	return nil
+}
+
+// runner 返回任务回调，每次触发时按名称查找当前注册的处理函数
+func (j *JobSet) runner(name string, args []string) func() {
+	return func() {
+		j.mu.Lock()
+		handler := j.handlers[name]
+		j.mu.Unlock()
+		if handler != nil {
+			handler(args)
+		}
+	}
+}
+
+// sameSpec 比较定义是否变化
+func sameSpec(a, b JobSpec) bool {
+	return a.Cron == b.Cron && a.Interval == b.Interval && a.Timezone == b.Timezone &&
+		a.CatchUp == b.CatchUp && a.Handler == b.Handler && slices.Equal(a.Args, b.Args)
+}
+
+func parseCatchUp(s string) (CatchUpPolicy, error) {
+	switch s {
+	case "", "once":
+		return CatchUpOnce, nil
+	case "all":
+		return CatchUpAll, nil
+	case "skip":
+		return CatchUpSkip, nil
+	default:
+		return 0, fmt.Errorf("invalid catch_up %q", s)
+	}
+}
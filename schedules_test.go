@@ -0,0 +1,111 @@
+//go:build !whtimer_tiny && !tinygo
+
+package whTimer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJobSetReload(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	jobs := timer.NewJobSet()
+	jobs.Register("report", func([]string) {})
+	jobs.Register("cleanup", func([]string) {})
+
+	err := jobs.LoadSchedules(strings.NewReader(`[
+		{"name": "daily", "cron": "0 0 9 * * *", "timezone": "UTC", "handler": "report"},
+		{"name": "gc", "interval": "1h", "catch_up": "skip", "handler": "cleanup"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	daily := jobs.jobs["daily"].entry
+	gc := jobs.jobs["gc"].entry
+
+	if err := jobs.LoadSchedules(strings.NewReader(`[{"name": "gc", "interval": "1m", "handler": "cleanup"}]`)); err == nil {
+		t.Fatal("duplicate LoadSchedules accepted")
+	}
+
+	err = jobs.ReloadSchedules(strings.NewReader(`[
+		{"name": "daily", "cron": "0 0 9 * * *", "timezone": "UTC", "handler": "report"},
+		{"name": "weekly", "cron": "0 0 9 * * 1", "handler": "report", "args": ["week"]}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := jobs.Names(); !slices.Equal(names, []string{"daily", "weekly"}) {
+		t.Fatalf("names=%v", names)
+	}
+	if jobs.jobs["daily"].entry != daily || daily.IsStopped() {
+		t.Fatal("unchanged job was replaced")
+	}
+	if !gc.IsStopped() {
+		t.Fatal("removed job still running")
+	}
+
+	for _, bad := range []string{
+		`[{"name": "x", "cron": "bad", "handler": "report"}]`,
+		`[{"name": "x", "interval": "1m", "handler": "missing"}]`,
+		`[{"name": "x", "handler": "report"}]`,
+		`[{"name": "x", "interval": "1m", "catch_up": "never", "handler": "report"}]`,
+		`[{"name": "x", "interval": "1m", "handler": "report"}, {"name": "x", "interval": "1m", "handler": "report"}]`,
+		`{`,
+	} {
+		if err := jobs.ReloadSchedules(strings.NewReader(bad)); err == nil {
+			t.Errorf("ReloadSchedules(%s) accepted", bad)
+		}
+	}
+	if names := jobs.Names(); len(names) != 2 || daily.IsStopped() {
+		t.Fatalf("failed reload modified jobs: %v", names)
+	}
+	jobs.Stop()
+}
+
+func TestJobSetYAML(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	jobs := timer.NewJobSet()
+	var got []string
+	jobs.Register("echo", func(args []string) { got = append(got, "v1:"+strings.Join(args, ",")) })
+
+	err := jobs.LoadSchedules(strings.NewReader(`
+# 注释与 YAML 列表
+- name: tick
+  interval: 10ms
+  handler: echo
+  args: [a, b]
+- name: daily
+  cron: "0 0 9 * * *"
+  timezone: UTC
+  handler: echo
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := jobs.Names(); !slices.Equal(names, []string{"daily", "tick"}) {
+		t.Fatalf("names=%v", names)
+	}
+
+	timer.Advance(base.Add(10 * time.Millisecond))
+	// 重新注册后已加载的任务使用新的处理函数
+	jobs.Register("echo", func(args []string) { got = append(got, "v2:"+strings.Join(args, ",")) })
+	timer.Advance(base.Add(20 * time.Millisecond))
+	if !slices.Equal(got, []string{"v1:a,b", "v2:a,b"}) {
+		t.Fatalf("runs=%v", got)
+	}
+
+	for _, bad := range []string{"- name: x\n  interval: 1m\n  handler: echo\n  unknown: 1\n", "", "name: x\n"} {
+		if err := jobs.ReloadSchedules(strings.NewReader(bad)); err == nil {
+			t.Errorf("ReloadSchedules(%q) accepted", bad)
+		}
+	}
+	jobs.Stop()
+}
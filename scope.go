@@ -0,0 +1,142 @@
+package whTimer
+
+import (
+	"sync"
+	"time"
+)
+
+// Scope 超时作用域，用于构建请求 → 子请求的截止时间树
+// 作用域到期或被取消时，其下所有子任务与子作用域一并取消
+type Scope struct {
+	timer  *Timer
+	parent *Scope
+
+	mu       sync.Mutex
+	done     bool
+	deadline EntryRef
+	entries  []EntryRef
+	children []*Scope
+}
+
+// NewScope 创建根作用域，timeout 到期后取消全部后代再执行 onTimeout
+// timeout <= 0 表示没有截止时间，只能通过 Cancel 结束
+func (t *Timer) NewScope(timeout time.Duration, onTimeout func()) *Scope {
+	s := &Scope{timer: t}
+	s.arm(timeout, onTimeout)
+	return s
+}
+
+// NewScope 创建子作用域，父作用域结束时随之取消
+// 父作用域已结束时返回的子作用域同样处于结束状态
+func (s *Scope) NewScope(timeout time.Duration, onTimeout func()) *Scope {
+	child := &Scope{timer: s.timer, parent: s}
+
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		child.done = true
+		return child
+	}
+	s.children = compactScopes(s.children)
+	s.children = append(s.children, child)
+	s.mu.Unlock()
+
+	child.arm(timeout, onTimeout)
+	return child
+}
+
+func (s *Scope) arm(timeout time.Duration, onTimeout func()) {
+	if timeout <= 0 {
+		return
+	}
+	ref := s.timer.addRef(s.timer.now().Add(timeout), func() {
+		if s.cancel() && onTimeout != nil {
+			onTimeout()
+		}
+	}, nil)
+
+	s.mu.Lock()
+	s.deadline = ref
+	s.mu.Unlock()
+}
+
+// AddEntry 在作用域内添加定时任务，作用域已结束时返回 nil
+func (s *Scope) AddEntry(delay time.Duration, callback func()) *Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done {
+		return nil
+	}
+
+	entry, ref := s.timer.addEntryRef(s.timer.now().Add(delay), callback)
+	s.entries = compactRefs(s.entries)
+	s.entries = append(s.entries, ref)
+	return entry
+}
+
+// Cancel 取消作用域及其全部后代，返回是否由本次调用结束
+func (s *Scope) Cancel() bool {
+	return s.cancel()
+}
+
+// Done 检查作用域是否已结束（到期或取消）
+func (s *Scope) Done() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.done
+}
+
+// Parent 获取父作用域，根作用域返回 nil
+func (s *Scope) Parent() *Scope {
+	return s.parent
+}
+
+func (s *Scope) cancel() bool {
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		return false
+	}
+	s.done = true
+	deadline, entries, children := s.deadline, s.entries, s.children
+	s.entries, s.children = nil, nil
+	s.mu.Unlock()
+
+	deadline.Cancel()
+	for _, ref := range entries {
+		ref.Cancel()
+	}
+	for _, child := range children {
+		child.cancel()
+	}
+	return true
+}
+
+// compactRefs 容量用尽时清理已结束的条目引用，避免长生命周期作用域无限增长
+func compactRefs(refs []EntryRef) []EntryRef {
+	if len(refs) < cap(refs) {
+		return refs
+	}
+	live := refs[:0]
+	for _, ref := range refs {
+		if s := ref.State(); s == StateQueued || s == StateScheduled {
+			live = append(live, ref)
+		}
+	}
+	clear(refs[len(live):])
+	return live
+}
+
+func compactScopes(scopes []*Scope) []*Scope {
+	if len(scopes) < cap(scopes) {
+		return scopes
+	}
+	live := scopes[:0]
+	for _, s := range scopes {
+		if !s.Done() {
+			live = append(live, s)
+		}
+	}
+	clear(scopes[len(live):])
+	return live
+}
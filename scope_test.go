@@ -0,0 +1,43 @@
+package whTimer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScope(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	var fired atomic.Int32
+	timedOut := make(chan struct{})
+	root := timer.NewScope(30*time.Millisecond, func() { close(timedOut) })
+	child := root.NewScope(0, nil)
+	root.AddEntry(10*time.Millisecond, func() { fired.Add(1) })
+	root.AddEntry(time.Hour, func() { fired.Add(100) })
+	grand := child.NewScope(time.Hour, func() { fired.Add(1000) })
+	late := grand.AddEntry(time.Hour, func() { fired.Add(10000) })
+
+	<-timedOut
+	if !child.Done() || !grand.Done() || late.State() != StateCanceled {
+		t.Fatalf("descendants not canceled: child=%v grand=%v late=%v", child.Done(), grand.Done(), late.State())
+	}
+	if fired.Load() != 1 {
+		t.Fatalf("fired=%d, want 1", fired.Load())
+	}
+	if root.AddEntry(time.Millisecond, func() {}) != nil {
+		t.Fatal("AddEntry on done scope should return nil")
+	}
+
+	s := timer.NewScope(time.Hour, func() { t.Error("canceled scope timed out") })
+	e := s.NewScope(time.Hour, nil).AddEntry(time.Hour, func() {})
+	ref := e.Ref()
+	if !s.Cancel() || s.Cancel() {
+		t.Fatal("Cancel should succeed exactly once")
+	}
+	if ref.State() != StateCanceled {
+		t.Fatalf("child entry state=%v", ref.State())
+	}
+}
@@ -0,0 +1,218 @@
+package whTimer
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ShardedTimer 由多个独立 Timer 组成的分片定时器，添加任务时轮询选择分片，
+// 每个分片拥有自己的队列与 run loop，添加吞吐随分片数（CPU 数）扩展
+// 分片数默认等于 GOMAXPROCS，可在运行中通过 Resize 调整，缩容时被移除分片上的任务迁移到其余分片
+type ShardedTimer struct {
+	handler func(*Entry)
+	opts    []Option
+
+	shards atomic.Pointer[[]*shard]
+	next   atomic.Uint64
+
+	mu      sync.Mutex // 串行化 Start、Stop 与 Resize
+	running bool
+	stopped bool
+	watch   chan struct{} // WatchGOMAXPROCS 的停止信号
+}
+
+// shard 单个分片，adding 与 retired 配合保证缩容后不再有任务进入被移除的分片
+type shard struct {
+	*Timer
+	adding  atomic.Int64
+	retired atomic.Bool
+}
+
+// NewShardedTimer 创建包含 n 个分片的定时器，n <= 0 时使用 GOMAXPROCS
+// 所有分片共享 handler 与 opts
+func NewShardedTimer(n int, handler func(*Entry), opts ...Option) *ShardedTimer {
+	s := &ShardedTimer{handler: handler, opts: opts}
+	shards := make([]*shard, shardCount(n))
+	for i := range shards {
+		shards[i] = s.newShard()
+	}
+	s.shards.Store(&shards)
+	return s
+}
+
+func shardCount(n int) int {
+	if n <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return n
+}
+
+func (s *ShardedTimer) newShard() *shard {
+	return &shard{Timer: NewTimer(s.handler, s.opts...)}
+}
+
+// Start 启动所有分片
+func (s *ShardedTimer) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running || s.stopped {
+		return
+	}
+	s.running = true
+	for _, sh := range *s.shards.Load() {
+		sh.Start()
+	}
+}
+
+// Stop 停止所有分片，仍未触发的任务标记为 Dropped
+func (s *ShardedTimer) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return
+	}
+	s.stopped = true
+	s.running = false
+	if s.watch != nil {
+		close(s.watch)
+		s.watch = nil
+	}
+	for _, sh := range *s.shards.Load() {
+		sh.Stop()
+	}
+}
+
+// acquire 选择一个分片并登记正在添加，调用方添加完成后需调用 release
+func (s *ShardedTimer) acquire() *shard {
+	for {
+		shards := *s.shards.Load()
+		sh := shards[s.next.Add(1)%uint64(len(shards))]
+		sh.adding.Add(1)
+		// 与 Resize 中先标记 retired 再等待 adding 归零配对：二者至少有一方能看到对方
+		if !sh.retired.Load() {
+			return sh
+		}
+		sh.adding.Add(-1)
+	}
+}
+
+func (sh *shard) release() {
+	sh.adding.Add(-1)
+}
+
+// With 在选中的分片上调用 fn，可使用 Timer 的任意添加接口 - Wait-Free
+// fn 返回后不应再向该 Timer 添加任务，分片可能已被移除
+func (s *ShardedTimer) With(fn func(t *Timer)) {
+	sh := s.acquire()
+	defer sh.release()
+	fn(sh.Timer)
+}
+
+// AddEntry 在轮询选中的分片上添加定时任务 - Wait-Free
+func (s *ShardedTimer) AddEntry(delay time.Duration, callback func()) *Entry {
+	sh := s.acquire()
+	defer sh.release()
+	return sh.AddEntry(delay, callback)
+}
+
+// AddEntryAt 在轮询选中的分片上添加指定时间触发的定时任务 - Wait-Free
+func (s *ShardedTimer) AddEntryAt(expireAt time.Time, callback func()) *Entry {
+	sh := s.acquire()
+	defer sh.release()
+	return sh.AddEntryAt(expireAt, callback)
+}
+
+// NumShards 返回当前分片数
+func (s *ShardedTimer) NumShards() int {
+	return len(*s.shards.Load())
+}
+
+// Pending 返回所有分片待触发的任务数之和
+func (s *ShardedTimer) Pending() uint64 {
+	var n uint64
+	for _, sh := range *s.shards.Load() {
+		n += sh.Pending()
+	}
+	return n
+}
+
+// Resize 将分片数调整为 n（n <= 0 时使用 GOMAXPROCS），返回从被移除分片迁移的任务数
+// 扩容只影响之后添加的任务；缩容时被移除分片上未触发的任务保留截止时间迁移到其余分片
+func (s *ShardedTimer) Resize(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return 0
+	}
+
+	n = shardCount(n)
+	old := *s.shards.Load()
+	if n == len(old) {
+		return 0
+	}
+
+	shards := make([]*shard, n)
+	copy(shards, old)
+	for i := len(old); i < n; i++ {
+		shards[i] = s.newShard()
+		if s.running {
+			shards[i].Start()
+		}
+	}
+	s.shards.Store(&shards)
+	if n > len(old) {
+		return 0
+	}
+
+	moved := 0
+	for i, sh := range old[n:] {
+		sh.retired.Store(true)
+		for sh.adding.Load() != 0 {
+			runtime.Gosched()
+		}
+		var entries []*Entry
+		if s.running {
+			entries = sh.StopAndDetach()
+		} else {
+			entries = sh.detachAll()
+		}
+		for j, e := range entries {
+			if shards[(i+j)%n].Adopt(e) {
+				moved++
+			}
+		}
+	}
+	return moved
+}
+
+// WatchGOMAXPROCS 每隔 interval 检查 GOMAXPROCS，变化时（例如容器 CPU 配额调整）按其调整分片数
+// 持续到 Stop 为止，重复调用时替换之前的检查
+func (s *ShardedTimer) WatchGOMAXPROCS(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return
+	}
+	if s.watch != nil {
+		close(s.watch)
+	}
+	stop := make(chan struct{})
+	s.watch = stop
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if procs := runtime.GOMAXPROCS(0); procs != s.NumShards() {
+					s.Resize(procs)
+				}
+			}
+		}
+	}()
+}
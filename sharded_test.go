@@ -0,0 +1,54 @@
+package whTimer
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestShardedTimerResize(t *testing.T) {
+	var executed atomic.Int64
+	s := NewShardedTimer(0, func(e *Entry) { e.Execute() })
+	if s.NumShards() != runtime.GOMAXPROCS(0) {
+		t.Fatalf("default shards = %d, want GOMAXPROCS", s.NumShards())
+	}
+	s.Resize(4)
+	s.Start()
+	defer s.Stop()
+
+	// 缩容与并发添加同时进行，任务既不丢失也不重复
+	stop := make(chan struct{})
+	var added atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				s.AddEntry(100*time.Millisecond, func() { executed.Add(1) })
+				added.Add(1)
+			}
+		}
+	}()
+	time.Sleep(5 * time.Millisecond)
+	s.Resize(1)
+	s.Resize(3)
+	close(stop)
+	wg.Wait()
+
+	if s.NumShards() != 3 {
+		t.Fatalf("shards = %d, want 3", s.NumShards())
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for executed.Load() != added.Load() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if executed.Load() != added.Load() {
+		t.Fatalf("executed %d of %d entries", executed.Load(), added.Load())
+	}
+}
@@ -0,0 +1,203 @@
+package whTimer
+
+import (
+	"sync"
+	"time"
+	"weak"
+)
+
+// payload 可序列化任务的数据，条目不持有闭包
+type payload []byte
+
+func (payload) Run() {}
+
+// spiller 远期任务溢出配置与状态，仅由 run loop 访问
+type spiller struct {
+	store   Store
+	maxLive uint64
+	horizon time.Duration
+	onError func(error)
+
+	// 已溢出条目的原句柄，取回时沿用以保持调用方的 *Entry 与 EntryRef 有效
+	// 只持有弱引用：调用方不再持有的条目照常被 GC 回收，溢出仍能降低内存占用
+	parked map[uint64]weak.Pointer[Entry]
+
+	// 溢出期间被取消的条目编号，句柄可能已被回收，取回时据此跳过
+	mu       sync.Mutex
+	canceled map[uint64]struct{}
+
+	retryAt time.Time // 取回失败后下一次重试的时间，避免 run loop 持续空转
+}
+
+// spillRetry 取回失败后的重试间隔
+const spillRetry = time.Second
+
+// cancel 记录溢出期间被取消的条目，可在任意 goroutine 调用
+func (s *spiller) cancel(id uint64) {
+	s.mu.Lock()
+	s.canceled[id] = struct{}{}
+	s.mu.Unlock()
+}
+
+// takeCanceled 检查并清除条目的取消记录
+func (s *spiller) takeCanceled(id uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.canceled[id]
+	delete(s.canceled, id)
+	return ok
+}
+
+// WithSpill 内存中的任务数达到 maxLive 时，将截止时间在 horizon 之后的可序列化任务写入 store，
+// 截止时间进入 horizon 内时再取回时间轮，使大量远期任务占用的内存有确定上界
+// 只有 AddSerializable 添加的任务会被溢出；store 的读写错误交给 onError（可为 nil），
+// 写入失败的任务留在内存中，读取失败时间隔 spillRetry 后重试
+// Stop 时已溢出的任务保留在 store 中，使用同一 store 的定时器会继续调度它们
+// 溢出的条目仍以 weak.Pointer 关联原 *Entry：调用方持有 AddSerializable 返回的句柄时该条目不会被回收，
+// 只有数据部分离开内存，节省的内存仅在调用方不再持有句柄（包括 EntryRef）时才完全兑现
+func WithSpill(store Store, maxLive int, horizon time.Duration, onError func(error)) Option {
+	return func(t *Timer) {
+		t.spill = &spiller{
+			store:    store,
+			maxLive:  uint64(max(maxLive, 0)),
+			horizon:  horizon,
+			onError:  onError,
+			parked:   make(map[uint64]weak.Pointer[Entry]),
+			canceled: make(map[uint64]struct{}),
+		}
+	}
+}
+
+// AddSerializable 添加只包含标签与数据的可序列化任务 - Wait-Free
+// 条目不持有回调，应通过 Handle 为 tag 注册 handler，在其中读取 Payload 处理；
+// 开启 WithSpill 时条目溢出期间只保留句柄、数据写入 store，取回后仍是同一条目，Cancel、Snooze 与 Ref 照常有效
+func (t *Timer) AddSerializable(tag string, delay time.Duration, data []byte) *Entry {
+	return t.AddSerializableAt(tag, t.now().Add(delay), data)
+}
+
+// AddSerializableAt 在指定时间添加可序列化任务 - Wait-Free
+func (t *Timer) AddSerializableAt(tag string, expireAt time.Time, data []byte) *Entry {
+	entry := t.newEntry(expireAt, nil)
+	entry.runnable = payload(data)
+	entry.delay = expireAt.Sub(t.now())
+	entry.tag = tag
+	return t.push(entry)
+}
+
+// Payload 获取 AddSerializable 添加的任务数据，其他任务返回 nil
+func (e *Entry) Payload() []byte {
+	p, _ := e.runnable.(payload)
+	return p
+}
+
+// spillOut 由 addToWheel 调用，条目满足溢出条件时写入 store，返回是否已移出内存
+func (t *Timer) spillOut(entry *Entry, now time.Time) bool {
+	s := t.spill
+	p, ok := entry.runnable.(payload)
+	if !ok || entry.critical || entry.handler != nil || entry.IsDone() || entry.state.Load()&flagWatched != 0 {
+		return false
+	}
+	if entry.expireAt.Sub(now) <= s.horizon || t.numEntries+uint64(len(t.heap)) < s.maxLive {
+		return false
+	}
+	if at, ok := entry.takeDeadline(); ok {
+		entry.expireAt = at
+	}
+
+	err := s.store.Put(SpilledEntry{ID: entry.id, Tag: entry.tag, Deadline: entry.expireAt, Payload: p})
+	if err != nil {
+		if s.onError != nil {
+			s.onError(err)
+		}
+		return false
+	}
+	// 条目保持待触发状态但交出数据，取回时由 rehydrate 恢复
+	entry.runnable = nil
+	entry.transit(StateScheduled)
+	entry.setFlag(flagSpilled)
+	if entry.IsDone() {
+		// 置位前已被取消，取消时未能记入索引
+		s.cancel(entry.id)
+	}
+	if t.leaks != nil {
+		t.leaks.forget(entry)
+	}
+	s.parked[entry.id] = weak.Make(entry)
+	return true
+}
+
+// rehydrate 将截止时间进入 horizon 内的已溢出任务重新放入时间轮
+func (t *Timer) rehydrate() {
+	s := t.spill
+	if s == nil || s.store.Len() == 0 {
+		return
+	}
+	now := t.now()
+	limit := now.Add(s.horizon)
+	if next, ok := s.store.Next(); !ok || next.After(limit) {
+		return
+	}
+
+	if now.Before(s.retryAt) {
+		return
+	}
+
+	spilled, err := s.store.TakeBefore(limit)
+	if err != nil {
+		s.retryAt = now.Add(spillRetry)
+		if s.onError != nil {
+			s.onError(err)
+		}
+	}
+	for _, r := range spilled {
+		entry := s.parked[r.ID].Value()
+		delete(s.parked, r.ID)
+		if entry != nil {
+			entry.clearFlag(flagSpilled)
+		}
+		if s.takeCanceled(r.ID) || (entry != nil && entry.IsDone()) {
+			// 溢出期间已被取消
+			if entry != nil {
+				t.release(entry)
+			}
+			continue
+		}
+		if entry == nil {
+			// 调用方已不再持有，或由其他定时器、重启前的进程写入
+			entry = t.newEntry(r.Deadline, nil)
+			entry.id = r.ID
+			entry.tag = r.Tag
+			entry.delay = r.Deadline.Sub(now)
+		}
+		entry.runnable = payload(r.Payload)
+		t.addToWheel(entry)
+	}
+}
+
+// nextRehydrate 返回下一次需要取回已溢出任务的时间
+func (t *Timer) nextRehydrate() *time.Time {
+	if t.spill == nil || t.spill.store.Len() == 0 {
+		return nil
+	}
+	next, ok := t.spill.store.Next()
+	if !ok {
+		return nil
+	}
+	at := next.Add(-t.spill.horizon)
+	if at.Before(t.spill.retryAt) {
+		at = t.spill.retryAt
+	}
+	return &at
+}
+
+// Spilled 返回已溢出到 store 中的任务数，未开启 WithSpill 时返回 0
+func (t *Timer) Spilled() int {
+	if t.spill == nil {
+		return 0
+	}
+	var n int
+	t.inspect(func() {
+		n = t.spill.store.Len()
+	})
+	return n
+}
@@ -0,0 +1,180 @@
+//go:build !whtimer_tiny && !tinygo
+
+package whTimer
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimerSpill(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spill.jsonl")
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fired []string
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithSpill(store, 2, time.Hour, func(err error) { t.Error(err) }))
+	timer.Handle("job", func(e *Entry) {
+		if !e.IsCanceled() {
+			fired = append(fired, string(e.Payload()))
+		}
+		e.Execute()
+	})
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	timer.AddEntry(time.Second, func() {})
+	timer.AddEntry(time.Second, func() {})
+	timer.AddEntry(5*time.Hour, func() {}) // 持有闭包的任务不会溢出
+	timer.AddSerializable("job", 30*time.Minute, []byte("near"))
+	canceled := timer.AddSerializable("job", 2*time.Hour, []byte("canceled"))
+	canceled.Cancel()
+	timer.AddSerializable("job", 4*time.Hour, []byte("b"))
+	timer.AddSerializable("job", 2*time.Hour, []byte("a"))
+	timer.Advance(base)
+
+	if n := timer.Spilled(); n != 2 {
+		t.Fatalf("Spilled=%d, want 2", n)
+	}
+	if p := timer.Pending(); p != 5 {
+		t.Fatalf("Pending=%d, want 5", p)
+	}
+
+	timer.Advance(base.Add(90 * time.Minute))
+	if n := timer.Spilled(); n != 1 {
+		t.Fatalf("Spilled=%d after rehydrate, want 1", n)
+	}
+	timer.Advance(base.Add(2 * time.Hour))
+	if !slices.Equal(fired, []string{"near", "a"}) {
+		t.Fatalf("fired=%q", fired)
+	}
+
+	// 重新打开同一文件恢复尚未取回的任务
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	store, err = OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if store.Len() != 1 {
+		t.Fatalf("reopened Len=%d, want 1", store.Len())
+	}
+	got, err := store.TakeBefore(base.Add(4 * time.Hour))
+	if err != nil || len(got) != 1 || string(got[0].Payload) != "b" || !got[0].Deadline.Equal(base.Add(4*time.Hour)) {
+		t.Fatalf("TakeBefore=%+v err=%v", got, err)
+	}
+	if store.Len() != 0 || store.size != 0 {
+		t.Fatalf("store not truncated: len=%d size=%d", store.Len(), store.size)
+	}
+}
+
+func TestTimerSpillHandle(t *testing.T) {
+	var fired []string
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithCompactFarFuture(time.Minute))
+	timer.Handle("job", func(e *Entry) {
+		fired = append(fired, string(e.Payload()))
+		e.Execute()
+	})
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	kept := timer.AddSerializable("job", time.Hour, []byte("kept"))
+	canceled := timer.AddSerializable("job", time.Hour, []byte("canceled")).Ref()
+	snoozed := timer.AddSerializable("job", time.Hour, []byte("snoozed"))
+	timer.Advance(base)
+	if n := timer.Spilled(); n != 3 {
+		t.Fatalf("Spilled=%d, want 3", n)
+	}
+
+	// 溢出期间原句柄仍可取消与推迟
+	if !canceled.Cancel() || !snoozed.Snooze(time.Hour) {
+		t.Fatal("spilled handle rejected Cancel or Snooze")
+	}
+	timer.Advance(base.Add(time.Hour))
+	if !slices.Equal(fired, []string{"kept"}) || kept.State() != StateFired {
+		t.Fatalf("fired=%q kept=%v", fired, kept.State())
+	}
+	timer.Advance(base.Add(2 * time.Hour))
+	if !slices.Equal(fired, []string{"kept", "snoozed"}) || snoozed.State() != StateFired {
+		t.Fatalf("fired=%q snoozed=%v", fired, snoozed.State())
+	}
+}
+
+func TestFileStoreTakeBeforeWriteFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spill.jsonl")
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	base := time.Unix(1000, 0)
+	for i := range 2 {
+		if err := store.Put(SpilledEntry{ID: uint64(i + 1), Deadline: base.Add(time.Duration(i) * time.Hour)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 只读句柄可以读出记录，但无法写入删除标记：取出失败，记录保留
+	rw := store.f
+	ro, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ro.Close()
+	store.f = ro
+	if got, err := store.TakeBefore(base); err == nil || len(got) != 0 || store.Len() != 2 {
+		t.Fatalf("TakeBefore=%+v err=%v Len=%d", got, err, store.Len())
+	}
+
+	store.f = rw
+	if got, err := store.TakeBefore(base); err != nil || len(got) != 1 || got[0].ID != 1 {
+		t.Fatalf("retry TakeBefore=%+v err=%v", got, err)
+	}
+	// 重新打开时只恢复未取出的记录
+	reopened, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if reopened.Len() != 1 {
+		t.Fatalf("reopened Len=%d, want 1", reopened.Len())
+	}
+}
+
+func TestTimerCompactFarFuture(t *testing.T) {
+	var fired []string
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithCompactFarFuture(time.Minute))
+	timer.Handle("job", func(e *Entry) {
+		fired = append(fired, string(e.Payload()))
+		e.Execute()
+	})
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	for i := 9; i >= 0; i-- {
+		timer.AddSerializable("job", time.Duration(i+1)*time.Hour, []byte{'0' + byte(i)})
+	}
+	timer.AddSerializable("job", time.Second, []byte("now"))
+	timer.Advance(base)
+	if n, p := timer.Spilled(), timer.Pending(); n != 10 || p != 1 {
+		t.Fatalf("Spilled=%d Pending=%d", n, p)
+	}
+
+	for h := 1; h <= 10; h++ {
+		timer.Advance(base.Add(time.Duration(h) * time.Hour))
+	}
+	want := []string{"now", "0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+	if !slices.Equal(fired, want) {
+		t.Fatalf("fired=%q", fired)
+	}
+	if timer.Spilled() != 0 {
+		t.Fatalf("Spilled=%d after all fired", timer.Spilled())
+	}
+}
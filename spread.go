@@ -0,0 +1,40 @@
+package whTimer
+
+import (
+	"math/rand/v2"
+	"time"
+	"unsafe"
+)
+
+// SpreadOver 将 n 次调用均匀分布在 window 内，第 i 次在 i*window/n 时执行
+// 适合刷新 N 个缓存键或重新注册 N 个客户端等需要避免惊群的场景，整批只需一次原子交换
+func (t *Timer) SpreadOver(window time.Duration, n int, fn func(i int)) []*Entry {
+	return t.SpreadOverJitter(window, n, 0, fn)
+}
+
+// SpreadOverJitter 同 SpreadOver，每次调用额外随机延后 [0, jitter)，jitter 不超过相邻间隔
+func (t *Timer) SpreadOverJitter(window time.Duration, n int, jitter time.Duration, fn func(i int)) []*Entry {
+	if n <= 0 {
+		return nil
+	}
+
+	step := window / time.Duration(n)
+	jitter = min(jitter, step)
+	now := t.now()
+	entries := make([]*Entry, n)
+	for i := range entries {
+		delay := time.Duration(i) * step
+		if jitter > 0 {
+			delay += rand.N(jitter)
+		}
+		entries[i] = t.newEntry(now.Add(delay), func() { fn(i) })
+		entries[i].delay = delay
+		if i > 0 {
+			entries[i-1].next = unsafe.Pointer(entries[i])
+		}
+	}
+
+	wasEmpty := t.queue.PushChain(entries[0], entries[n-1])
+	t.wakeIfNeeded(wasEmpty, now)
+	return entries
+}
@@ -0,0 +1,21 @@
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerSpreadOver(t *testing.T) {
+	base := time.Now()
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Advance(base)
+
+	var fired []int
+	timer.SpreadOverJitter(100*time.Millisecond, 10, 5*time.Millisecond, func(i int) { fired = append(fired, i) })
+	for step := 0; step < 10; step++ {
+		timer.Advance(base.Add(time.Duration(step)*10*time.Millisecond + 9*time.Millisecond))
+		if len(fired) != step+1 || fired[step] != step {
+			t.Fatalf("after %d steps fired=%v", step+1, fired)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package whTimer
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// poolCounters Entry 对象池计数，进程内所有定时器共享
+// 每次添加与回收都要计数，gets 与 puts 按缓存行分片，避免各核的生产者争用同一缓存行
+var poolCounters struct {
+	gets   shardedCounter
+	misses atomic.Uint64 // 对象池为空、新分配的次数，只在分配时计数
+	puts   shardedCounter
+}
+
+// counterShards 计数器分片数
+const counterShards = 16
+
+// shardedCounter 按缓存行分片的计数器，Add 随机选择分片，Load 汇总全部分片
+type shardedCounter [counterShards]struct {
+	n atomic.Uint64
+	_ [max(cacheLineSize-8, 0)]byte
+}
+
+// Add 累加到随机分片 - O(1)
+func (c *shardedCounter) Add(n uint64) {
+	c[rand.Uint32()%counterShards].n.Add(n)
+}
+
+// Load 返回全部分片之和，与 Add 并发时为近似值
+func (c *shardedCounter) Load() uint64 {
+	var sum uint64
+	for i := range c {
+		sum += c[i].n.Load()
+	}
+	return sum
+}
+
+// Stats 定时器运行统计
+type Stats struct {
+	Pending uint64 // 待触发的任务数
+	Fired   uint64 // 已交给 handler 的任务数
+
+	// Entry 对象池统计，进程内所有定时器共享；WithPooling(false) 的定时器不经过对象池
+	PoolHits   uint64 // 从对象池复用的次数
+	PoolMisses uint64 // 对象池为空、新分配的次数
+	PoolIdle   int    // 对象池中空闲条目的估计数量，sync.Pool 在 GC 时丢弃的条目无法计入
+}
+
+// Stats 返回运行统计
+func (t *Timer) Stats() Stats {
+	var s Stats
+	t.inspect(func() {
+		s.Pending = t.pending()
+		s.Fired = t.fired
+	})
+	gets, misses := poolCounters.gets.Load(), poolCounters.misses.Load()
+	s.PoolHits = gets - min(misses, gets)
+	s.PoolMisses = misses
+	s.PoolIdle = poolIdle()
+	return s
+}
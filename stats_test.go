@@ -0,0 +1,33 @@
+package whTimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerStats(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	before := timer.Stats()
+	for range 10 {
+		timer.AddEntry(time.Millisecond, func() {})
+	}
+	timer.AddEntry(time.Hour, func() {})
+	timer.Advance(base.Add(time.Second))
+
+	s := timer.Stats()
+	if s.Pending != 1 || s.Fired != 10 {
+		t.Fatalf("pending=%d fired=%d", s.Pending, s.Fired)
+	}
+	if gets := (s.PoolHits + s.PoolMisses) - (before.PoolHits + before.PoolMisses); gets != 11 {
+		t.Fatalf("pool gets=%d, want 11", gets)
+	}
+
+	unpooled := NewTimer(func(e *Entry) { e.Execute() }, WithPooling(false))
+	unpooled.AddEntry(time.Hour, func() {})
+	if after := unpooled.Stats(); after.PoolHits+after.PoolMisses != s.PoolHits+s.PoolMisses {
+		t.Fatal("unpooled timer went through the pool")
+	}
+}
@@ -0,0 +1,26 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// SpilledEntry 移出内存保存的任务记录，只包含可序列化的字段
+type SpilledEntry struct {
+	ID       uint64
+	Tag      string
+	Deadline time.Time
+	Payload  []byte
+}
+
+// Store 远期任务的外部存储，由 run loop 单线程调用
+type Store interface {
+	// Put 保存一条记录
+	Put(e SpilledEntry) error
+	// Next 返回最早的截止时间，没有记录时 ok 为 false
+	Next() (deadline time.Time, ok bool)
+	// TakeBefore 取出并删除截止时间不晚于 deadline 的记录
+	// 无法删除时应返回错误并保留记录，下一轮重试；返回的记录均视为已取出，即使同时返回错误
+	TakeBefore(deadline time.Time) ([]SpilledEntry, error)
+	// Len 返回记录数
+	Len() int
+}
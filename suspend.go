@@ -0,0 +1,91 @@
+package whTimer
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// ResumePolicy 检测到系统挂起恢复（笔记本休眠、虚拟机暂停）后对错过任务的处理策略
+type ResumePolicy int
+
+const (
+	ResumeFireAll ResumePolicy = iota // 立即触发所有错过的任务（默认）
+	ResumeSpread                      // 在恢复窗口内随机分散触发，避免惊群
+	ResumeNotify                      // 不触发，通过 OnResume 交给应用决定
+)
+
+// ResumeEvent 挂起恢复事件
+type ResumeEvent struct {
+	Gap    time.Duration // 实际唤醒与预期唤醒之间的间隔
+	Missed int           // 挂起期间到期的任务数
+	// Entries 仅 ResumeNotify 策略下填充，应用需对每个条目调用 Execute 或 Release
+	Entries []*Entry
+}
+
+// resumeConfig WithSuspendDetection、WithResumePolicy 与 WithOnResume 的配置，仅由 run loop 读取
+type resumeConfig struct {
+	threshold time.Duration // 唤醒间隔超过该值视为挂起恢复，0 表示关闭
+	policy    ResumePolicy
+	window    time.Duration
+	onResume  func(ResumeEvent)
+}
+
+// detectResume 比较预期唤醒时间与实际唤醒时间，间隔超过阈值时按策略处理
+// start 为开始等待时的时间，用于识别单调时钟在挂起期间停止走动的情况
+func (t *Timer) detectResume(start, deadline time.Time) {
+	if t.resume.threshold <= 0 {
+		return
+	}
+
+	now := time.Now()
+	gap := now.Sub(deadline)
+	if jump := now.Round(0).Sub(start.Round(0)) - now.Sub(start); jump > gap {
+		gap = jump
+	}
+	if gap > t.resume.threshold {
+		t.handleResume(gap)
+	}
+}
+
+func (t *Timer) handleResume(gap time.Duration) {
+	now := t.now()
+
+	var missed []*Entry
+	for len(t.heap) > 0 && !t.dueAt(t.heap[0]).After(now) {
+		missed = append(missed, t.heap.pop(t))
+	}
+	if t.wheel != nil && t.numEntries > 0 {
+		interval := uint64(now.Sub(t.start).Milliseconds())
+		count := t.wheel.HandleExpiredEntries(func(e *Entry) {
+			missed = append(missed, e)
+		}, interval)
+		t.numEntries -= uint64(count)
+		t.maintenance(interval)
+	}
+
+	event := ResumeEvent{Gap: gap, Missed: len(missed)}
+	switch {
+	case t.resume.policy == ResumeSpread && t.resume.window > 0:
+		// 交给 rearmRefreshed 重新放入时间轮
+		for _, e := range missed {
+			e.expireAt = now.Add(rand.N(t.resume.window))
+			t.rearm = append(t.rearm, e)
+		}
+		missed = nil
+	case t.resume.policy == ResumeNotify:
+		for _, e := range missed {
+			e.detach()
+		}
+		event.Entries = missed
+		missed = nil
+	}
+
+	if t.resume.onResume != nil {
+		t.enterCallback()
+		t.resume.onResume(event)
+		t.exitCallback()
+	}
+	for _, e := range missed {
+		t.dispatch(e)
+	}
+}
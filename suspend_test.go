@@ -0,0 +1,57 @@
+package whTimer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimerResumePolicy(t *testing.T) {
+	base := time.Now()
+
+	var event ResumeEvent
+	var fired atomic.Int32
+	timer := NewTimer(func(e *Entry) { e.Execute() },
+		WithSuspendDetection(time.Second),
+		WithResumePolicy(ResumeNotify, 0),
+		WithOnResume(func(ev ResumeEvent) { event = ev }))
+	timer.Advance(base)
+	for i := 0; i < 3; i++ {
+		timer.AddEntry(10*time.Millisecond, func() { fired.Add(1) })
+	}
+	timer.AddEntry(time.Hour, func() { fired.Add(1) })
+	timer.Advance(base)
+
+	// 模拟挂起: 时钟直接跳过到期时间
+	timer.manualNow.Store(base.Add(time.Minute).UnixNano())
+	timer.handleResume(time.Minute)
+	if event.Missed != 3 || len(event.Entries) != 3 || fired.Load() != 0 {
+		t.Fatalf("notify: missed=%d entries=%d fired=%d", event.Missed, len(event.Entries), fired.Load())
+	}
+	for _, e := range event.Entries {
+		e.Execute()
+	}
+	timer.Advance(base.Add(time.Minute))
+	if fired.Load() != 3 || timer.Pending() != 1 {
+		t.Fatalf("fired=%d pending=%d", fired.Load(), timer.Pending())
+	}
+
+	fired.Store(0)
+	spread := NewTimer(func(e *Entry) { e.Execute() },
+		WithResumePolicy(ResumeSpread, 100*time.Millisecond))
+	spread.Advance(base)
+	for i := 0; i < 10; i++ {
+		spread.AddEntry(10*time.Millisecond, func() { fired.Add(1) })
+	}
+	spread.Advance(base)
+	now := base.Add(time.Minute)
+	spread.manualNow.Store(now.UnixNano())
+	spread.handleResume(time.Minute)
+	if fired.Load() != 0 {
+		t.Fatalf("spread fired %d immediately", fired.Load())
+	}
+	spread.Advance(now.Add(200 * time.Millisecond))
+	if fired.Load() != 10 {
+		t.Fatalf("spread fired %d, want 10", fired.Load())
+	}
+}
@@ -1,39 +1,137 @@
 package whTimer
 
 import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
 	"sync/atomic"
 	"time"
+	"unsafe"
 )
 
 // MaxDuration 最大支持的定时时长
 var MaxDuration = time.Duration(maxMs[MaxLevel]) * time.Millisecond
 
+// TryAddEntry 与 TryAddEntryAt 返回的错误
+var (
+	ErrDelayTooLong = errors.New("whTimer: delay exceeds MaxDuration") // 延迟达到或超过 MaxDuration
+	ErrInvalidDelay = errors.New("whTimer: delay must be positive")    // 延迟为零或负数，或过期时间不晚于当前时间
+	ErrNilCallback  = errors.New("whTimer: nil callback")              // 回调为 nil
+	ErrTimerStopped = errors.New("whTimer: add on stopped Timer")      // 定时器已停止，任务不会触发
+)
+
 // Timer 高性能定时器
+// 字段按写入方分组并以缓存行隔开，避免 run loop 的频繁写入使生产者读取的缓存行失效
 type Timer struct {
+	// 生产者热路径只读字段
+	queue     *MPSCQueue
+	wakeChan  chan struct{}
+	noPooling bool
+	manualNow atomic.Int64 // 手动驱动模式下最近一次 Advance 的时间（UnixNano），很少写入
+	clock     atomic.Pointer[virtualClock]
+	_         [cacheLineSize]byte
+
+	// run loop 每轮写入、生产者每次添加读取
+	sleepUntil atomic.Int64
+	wheelStart atomic.Int64 // 时间轮起点 start 的 UnixNano，供 TryAddEntry 判断是否会被截断
+	_          [max(cacheLineSize-16, 0)]byte
+
+	// Start/Stop 及 run loop 启停时写入
+	running       atomic.Bool
+	loopActive    atomic.Bool  // run loop goroutine 是否存活
+	inspectWanted atomic.Int32 // 等待 run loop 处理的 inspect 请求数
+	inCallback    atomic.Bool  // run loop 正在调用 handler 或钩子，inspect 可在调用方执行
+	inlineN       atomic.Int32 // 正在调用方执行的 inspect 数
+	pendingN      atomic.Uint64
+	_             [max(cacheLineSize-32, 0)]byte
+
+	// run loop 私有状态
 	wheel      *Wheel
 	start      time.Time
 	numEntries uint64
+	fired      uint64   // 已交给 handler 的任务数
+	rearm      []*Entry // 本轮因 Refresh 需要重新放入时间轮的任务
+	reentered  bool     // handler 在 runCycle 中经 inspect 重入，期间不排空队列、不调整时间轮结构
+	precise    []*Entry // 等待精确触发的关键任务，按 expireAt 升序
+	heap       entryHeap
+	heapLimit  int // 小于该数量时使用堆代替时间轮，0 表示关闭
+
+	handler     func(*Entry)
+	nextHandler atomic.Pointer[func(*Entry)] // SetHandler 设置、等待 run loop 应用的 handler
+	routes      atomic.Pointer[routeTable]   // Handle 注册的按标签路由
+	routesMu    sync.Mutex
+
+	chain        atomic.Pointer[EntryHandler] // Use 注册的中间件链
+	middlewares  []func(EntryHandler) EntryHandler
+	middlewareMu sync.Mutex
+
+	inspectChan chan func()
+	inlineMu    sync.Mutex // 串行化在调用方执行的 inspect
+	stopChan    chan struct{}
+	doneChan    chan struct{}
+	loopWG      sync.WaitGroup
+	stopCtx     context.Context // Stop 时取消，传递给进行中的 ctx 回调
+	stopCancel  context.CancelFunc
+	inflight    inflight
+
+	onDemand       bool
+	wait           WaitStrategy
+	lockOSThread   bool
+	cpuAffinity    []int
+	affinityErr    atomic.Pointer[error] // 最近一次绑定 CPU 的结果
+	highResolution bool
+	criticalLead   time.Duration
+	fireTimestamps bool
+	retainCancel   bool
+	stopGrace      time.Duration
+	hotOption      int // 可热更新的配置项执行时加一，Reconfigure 据此拒绝其他配置项
+	minLevel       int // 时间轮创建与降级时保持的最低层级，由 WithAutoTune 选定
+
+	crons sync.Map // *CronEntry -> struct{}，周期任务登记表
+
+	// 可选功能按功能分组，未开启时为零值或 nil，各阶段自行检查
+	hooks        timerHooks
+	resume       resumeConfig
+	missedRun    missedRunWatch
+	rate         rateLimit
+	order        stableOrder
+	batch        batcher
+	housekeeping housekeeping
+	groups       map[string]*concurrencyGroup // WithConcurrencyLimit 设置的按标签并发上限，创建后只读
+	drift        *driftMonitor
+	delayStats   *DelayStats
+	tuner        *autoTuner
+	leaks        *leakDetector
+	spill        *spiller
+}
 
-	queue *MPSCQueue
-
-	wakeChan   chan struct{}
-	stopChan   chan struct{}
-	doneChan   chan struct{}
-	sleepUntil atomic.Int64
-
-	handler func(*Entry)
-	running atomic.Bool
+// timerHooks 由 run loop 调用的用户回调及其参数
+type timerHooks struct {
+	onClamp       func(entry *Entry, requested time.Time)
+	lateThreshold time.Duration
+	onLate        func(entry *Entry, drift time.Duration)
+	beforeFire    func(entry *Entry) bool
+	vetoRetry     time.Duration
 }
 
 // NewTimer 创建新的定时器
-func NewTimer(handler func(*Entry)) *Timer {
-	return &Timer{
-		queue:    NewMPSCQueue(),
-		wakeChan: make(chan struct{}, 1),
-		stopChan: make(chan struct{}),
-		doneChan: make(chan struct{}),
-		handler:  handler,
+func NewTimer(handler func(*Entry), opts ...Option) *Timer {
+	t := &Timer{
+		queue:        NewMPSCQueue(),
+		wakeChan:     make(chan struct{}, 1),
+		inspectChan:  make(chan func()),
+		stopChan:     make(chan struct{}),
+		doneChan:     make(chan struct{}),
+		handler:      handler,
+		wait:         SleepWait{},
+		criticalLead: defaultCriticalLead,
 	}
+	t.stopCtx, t.stopCancel = context.WithCancel(context.Background())
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
 }
 
 // Start 启动定时器
@@ -41,28 +139,296 @@ func (t *Timer) Start() {
 	if t.running.Swap(true) {
 		return
 	}
+	if t.highResolution {
+		beginHighResolution()
+	}
+	t.loopActive.Store(true)
+	t.loopWG.Add(1)
 	go t.run()
 }
 
-// Stop 停止定时器
+// Stop 停止定时器，仍未触发的任务标记为 Dropped
+// 开启泄漏检测时先报告疑似泄漏的条目；进行中的 AddEntryCtxFn 回调的 context 被取消，
+// 设置了 WithStopGrace 时最多等待宽限期让其返回
 func (t *Timer) Stop() {
+	t.stop(func() {
+		t.reportLeaks()
+		t.dropAll()
+	})
+}
+
+// StopAndDetach 停止定时器并交出所有未触发的条目，截止时间与元数据保持不变
+// 返回的条目可通过其他 Timer 的 Adopt 继续调度，也可持久化或检查后 Release
+func (t *Timer) StopAndDetach() []*Entry {
+	var entries []*Entry
+	t.stop(func() {
+		entries = t.detachAll()
+	})
+	return entries
+}
+
+// detachAll 交出所有未触发的条目，仅在 run loop 停止后或从未启动时调用
+func (t *Timer) detachAll() []*Entry {
+	var entries []*Entry
+	t.drainAll(func(e *Entry) {
+		if at, ok := e.takeDeadline(); ok {
+			e.expireAt = at
+		}
+		if e.IsDone() {
+			if e.detach() {
+				e.tryRecycle()
+			}
+			return
+		}
+		e.detach()
+		entries = append(entries, e)
+	})
+	return entries
+}
+
+// stop 等待 run loop 退出后由 drain 处理剩余条目
+func (t *Timer) stop(drain func()) {
 	if !t.running.Swap(false) {
 		return
 	}
 	close(t.stopChan)
-	<-t.doneChan
+	// 先取消再等待 run loop，handler 在 run loop 内同步执行的回调也能及时返回
+	t.stopCancel()
+	t.loopWG.Wait()
+	t.inflight.wait(t.stopGrace)
+	drain()
+	t.publishPending()
+	if t.highResolution {
+		endHighResolution()
+	}
+	close(t.doneChan)
+}
+
+// CPUAffinityErr 返回 run loop 最近一次按 WithCPUAffinity 绑定 CPU 的错误，成功或未设置时返回 nil
+func (t *Timer) CPUAffinityErr() error {
+	if err := t.affinityErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// ensureLoop 按需模式下 run loop 已退出时重新启动
+func (t *Timer) ensureLoop() {
+	if t.loopActive.Load() || !t.running.Load() {
+		return
+	}
+	if t.loopActive.CompareAndSwap(false, true) {
+		t.loopWG.Add(1)
+		go t.run()
+	}
+}
+
+// park 按需模式下空闲时让 run loop 退出，返回 false 表示期间有新工作需要继续运行
+func (t *Timer) park() bool {
+	t.loopActive.Store(false)
+	if t.queue.IsEmpty() && t.inspectWanted.Load() == 0 {
+		return true
+	}
+	// 新工作可能已由生产者启动了新的 run loop
+	return !t.loopActive.CompareAndSwap(false, true)
 }
 
 // AddEntry 添加定时任务 - Wait-Free
 func (t *Timer) AddEntry(delay time.Duration, callback func()) *Entry {
-	return t.AddEntryAt(time.Now().Add(delay), callback)
+	entry := t.newEntry(t.now().Add(delay), callback)
+	entry.delay = delay
+	return t.push(entry)
+}
+
+// TryAddEntry 校验参数后添加定时任务 - Wait-Free
+// 与 AddEntry 静默接受不同，回调为 nil、延迟不为正、过期时间超出时间轮可表示的范围或定时器已停止时返回错误
+func (t *Timer) TryAddEntry(delay time.Duration, callback func()) (*Entry, error) {
+	now := t.now()
+	if err := t.checkAdd(now, now.Add(delay), callback); err != nil {
+		return nil, err
+	}
+	entry := t.newEntry(now.Add(delay), callback)
+	entry.delay = delay
+	return t.push(entry), nil
+}
+
+// TryAddEntryAt 校验参数后在指定时间添加定时任务，规则同 TryAddEntry - Wait-Free
+func (t *Timer) TryAddEntryAt(expireAt time.Time, callback func()) (*Entry, error) {
+	now := t.now()
+	if err := t.checkAdd(now, expireAt, callback); err != nil {
+		return nil, err
+	}
+	entry := t.newEntry(expireAt, callback)
+	entry.delay = expireAt.Sub(now)
+	return t.push(entry), nil
+}
+
+// checkAdd 校验待添加的任务，now 取自定时器时钟
+// 时间轮按起点 start 计算可表示的范围，start 可能落后 now 至多一个最高层槽位，
+// 因此除距 now 的延迟外还需按 start 判断是否会被 placeInWheel 截断；start 只会后移，按旧值判断偏保守
+func (t *Timer) checkAdd(now, expireAt time.Time, callback func()) error {
+	switch {
+	case callback == nil:
+		return ErrNilCallback
+	case !expireAt.After(now):
+		return ErrInvalidDelay
+	case expireAt.Sub(now) >= MaxDuration:
+		return ErrDelayTooLong
+	case t.isStopped():
+		return ErrTimerStopped
+	}
+	if start := t.wheelStart.Load(); start != 0 && ceilMs(expireAt.Sub(time.Unix(0, start))) >= maxMs[MaxLevel] {
+		return ErrDelayTooLong
+	}
+	return nil
+}
+
+// isStopped 检查是否已调用 Stop
+func (t *Timer) isStopped() bool {
+	select {
+	case <-t.stopChan:
+		return true
+	default:
+		return false
+	}
 }
 
 // AddEntryAt 在指定时间添加定时任务 - Wait-Free
 func (t *Timer) AddEntryAt(expireAt time.Time, callback func()) *Entry {
-	entry := NewEntry(expireAt, callback)
+	entry := t.newEntry(expireAt, callback)
+	entry.delay = expireAt.Sub(t.now())
+	return t.push(entry)
+}
+
+// AddEntryRef 添加定时任务并只返回 EntryRef - Wait-Free
+// 调用方不持有 *Entry，条目执行或取消后自动回收复用，适合大量短期任务
+func (t *Timer) AddEntryRef(delay time.Duration, callback func()) EntryRef {
+	return t.addRef(t.now().Add(delay), callback, nil)
+}
+
+// AddRunnableRef 添加 Runnable 定时任务并只返回 EntryRef，条目执行或取消后自动回收 - Wait-Free
+// 对象池预热后稳定运行时零分配
+func (t *Timer) AddRunnableRef(delay time.Duration, r Runnable) EntryRef {
+	return t.addRef(t.now().Add(delay), nil, r)
+}
+
+// addRef 添加只以 EntryRef 交给调用方的条目，执行或取消后自动回收
+func (t *Timer) addRef(expireAt time.Time, callback func(), r Runnable) EntryRef {
+	entry := t.newEntry(expireAt, callback)
+	entry.runnable = r
+	entry.delay = expireAt.Sub(t.now())
+	entry.hide()
+	ref := entry.Ref()
+	t.push(entry)
+	return ref
+}
 
+// addEntryRef 添加定时任务并返回入队前获取的引用
+// 入队后条目可能立即触发并被回收复用，此后再调用 Ref 会得到其他任务的引用
+func (t *Timer) addEntryRef(expireAt time.Time, callback func()) (*Entry, EntryRef) {
+	entry := t.newEntry(expireAt, callback)
+	entry.delay = expireAt.Sub(t.now())
+	ref := entry.Ref()
+	t.push(entry)
+	return entry, ref
+}
+
+// AddRunnable 添加 Runnable 定时任务 - Wait-Free
+// 与 AddEntry 不同，无需为回调分配闭包，条目来自对象池时零分配
+func (t *Timer) AddRunnable(delay time.Duration, r Runnable) *Entry {
+	entry := t.newEntry(t.now().Add(delay), nil)
+	entry.runnable = r
+	entry.delay = delay
+	return t.push(entry)
+}
+
+// AddRunnableAt 在指定时间添加 Runnable 定时任务 - Wait-Free
+func (t *Timer) AddRunnableAt(expireAt time.Time, r Runnable) *Entry {
+	entry := t.newEntry(expireAt, nil)
+	entry.runnable = r
+	entry.delay = expireAt.Sub(t.now())
+	return t.push(entry)
+}
+
+// AddTaggedEntry 添加带标签的定时任务 - Wait-Free
+func (t *Timer) AddTaggedEntry(tag string, delay time.Duration, callback func()) *Entry {
+	entry := t.newEntry(t.now().Add(delay), callback)
+	entry.delay = delay
+	entry.tag = tag
+	return t.push(entry)
+}
+
+// AddTaggedEntryAt 在指定时间添加带标签的定时任务 - Wait-Free
+func (t *Timer) AddTaggedEntryAt(tag string, expireAt time.Time, callback func()) *Entry {
+	entry := t.newEntry(expireAt, callback)
+	entry.delay = expireAt.Sub(t.now())
+	entry.tag = tag
+	return t.push(entry)
+}
+
+// AddEntries 批量添加相同延迟的定时任务，整批只需一次原子交换
+func (t *Timer) AddEntries(delay time.Duration, callbacks ...func()) []*Entry {
+	if len(callbacks) == 0 {
+		return nil
+	}
+
+	expireAt := t.now().Add(delay)
+	entries := make([]*Entry, len(callbacks))
+	for i, callback := range callbacks {
+		entries[i] = t.newEntry(expireAt, callback)
+		entries[i].delay = delay
+		if i > 0 {
+			entries[i-1].next = unsafe.Pointer(entries[i])
+		}
+	}
+
+	wasEmpty := t.queue.PushChain(entries[0], entries[len(entries)-1])
+	t.wakeIfNeeded(wasEmpty, expireAt)
+	return entries
+}
+
+// newEntry 按配置从对象池获取或直接分配条目
+func (t *Timer) newEntry(expireAt time.Time, callback func()) *Entry {
+	var entry *Entry
+	if t.noPooling {
+		entry = newUnpooledEntry(expireAt, callback)
+	} else {
+		entry = NewEntry(expireAt, callback)
+	}
+	entry.owner = t
+	if t.leaks != nil {
+		t.leaks.sample(entry, t.now())
+	}
+	return entry
+}
+
+// Preallocate 预先向 Entry 对象池放入 n 个条目，使启动后的第一波流量无需分配 - O(n)
+// 对象池由进程内所有定时器共享，sync.Pool 在 GC 时可能丢弃空闲条目，应在流量到来前不久调用；
+// 精简构建下超出 TinyPoolSize 的部分被丢弃，WithPooling(false) 的定时器调用无效
+// 时间轮层级随任务按需升降且为空时整体释放，无需预先建立
+func (t *Timer) Preallocate(n int) {
+	if t.noPooling {
+		return
+	}
+	// 逐个分配，避免整块内存因单个条目仍被引用而无法释放
+	for range n {
+		putEntry(&Entry{})
+	}
+}
+
+func (t *Timer) push(entry *Entry) *Entry {
+	// 入队后条目可能立即被 run loop 触发并回收，过期时间须在入队前读取
+	expireAt := entry.expireAt
 	wasEmpty := t.queue.Push(entry)
+	t.wakeIfNeeded(wasEmpty, expireAt)
+	return entry
+}
+
+// wakeIfNeeded 队列由空变为非空或新任务早于当前睡眠截止时间时唤醒 run loop
+func (t *Timer) wakeIfNeeded(wasEmpty bool, expireAt time.Time) {
+	if t.onDemand {
+		t.ensureLoop()
+	}
 
 	sleepUntil := t.sleepUntil.Load()
 	if wasEmpty || (sleepUntil > 0 && expireAt.UnixNano() < sleepUntil) {
@@ -71,85 +437,239 @@ func (t *Timer) AddEntryAt(expireAt time.Time, callback func()) *Entry {
 		default:
 		}
 	}
-
-	return entry
 }
 
 func (t *Timer) run() {
-	defer close(t.doneChan)
+	defer t.loopWG.Done()
+
+	if t.lockOSThread {
+		runtime.LockOSThread()
+		pinned := false
+		if len(t.cpuAffinity) > 0 {
+			err := setAffinity(t.cpuAffinity)
+			t.affinityErr.Store(&err)
+			pinned = err == nil
+		}
+		// 绑定了 CPU 的线程不能交还调度器，否则无关的 goroutine 会运行在受限的 CPU 上；
+		// goroutine 退出时仍处于锁定状态，运行时随之销毁该线程
+		if !pinned {
+			defer runtime.UnlockOSThread()
+		}
+	}
 
-	timer := time.NewTimer(time.Hour)
-	timer.Stop()
-	defer timer.Stop()
+	waiter := newWaiter(t)
+	defer waiter.release()
 
 	for {
-		t.drainQueue()
-		t.handleExpired()
+		t.runCycle()
+
+		// 有生产者尚未完成入队链接或持续入队，让出调度后继续排空，避免丢失唤醒；
+		// 期间仍响应 Stop 与 inspect，不被持续入队的生产者饿死
+		if !t.queue.IsEmpty() {
+			select {
+			case <-t.stopChan:
+				return
+			case fn := <-t.inspectChan:
+				fn()
+			default:
+				runtime.Gosched()
+			}
+			continue
+		}
 
 		nextWake := t.calculateNextWake()
+		wait := t.wait
+		if d := t.nextDeferred(); d != nil && (nextWake == nil || d.Before(*nextWake)) {
+			nextWake = d
+		}
+		if r := t.nextRehydrate(); r != nil && (nextWake == nil || r.Before(*nextWake)) {
+			nextWake = r
+		}
+		if p := t.nextPrecise(); p != nil && (nextWake == nil || p.Before(*nextWake)) {
+			nextWake = p
+			wait = SpinWait{}
+		}
 
 		if nextWake == nil {
 			t.sleepUntil.Store(0)
+			if t.onDemand && t.park() {
+				return
+			}
 			select {
 			case <-t.stopChan:
 				return
 			case <-t.wakeChan:
 				continue
+			case fn := <-t.inspectChan:
+				fn()
+				continue
 			}
 		}
 
 		t.sleepUntil.Store(nextWake.UnixNano())
 
-		sleepDuration := time.Until(*nextWake)
-		if sleepDuration <= 0 {
+		if !t.now().Before(*nextWake) {
 			continue
 		}
 
-		timer.Reset(sleepDuration)
-
-		select {
-		case <-t.stopChan:
-			timer.Stop()
-			return
-		case <-timer.C:
-		case <-t.wakeChan:
-			if !timer.Stop() {
+		deadline := *nextWake
+		if c := t.clock.Load(); c != nil {
+			// 虚拟时间暂停时只等待事件，恢复后由 updateClock 唤醒
+			wall, ok := c.toWall(deadline)
+			if !ok {
 				select {
-				case <-timer.C:
-				default:
+				case <-t.stopChan:
+					return
+				case <-t.wakeChan:
+				case fn := <-t.inspectChan:
+					fn()
 				}
+				continue
 			}
+			deadline = wall
+		}
+
+		waitStart := time.Now()
+		if !wait.Wait(waiter, deadline) {
+			return
 		}
+		t.detectResume(waitStart, deadline)
 	}
 }
 
-func (t *Timer) drainQueue() {
-	t.queue.DrainAll(func(entry *Entry) {
-		t.addToWheel(entry)
-	})
+// maxCycleRounds 一轮内因回调添加任务而重复排空的最大次数
+const maxCycleRounds = 16
+
+// maxDrainBatch 每次排空队列的最大任务数，也是一轮内继续排空的任务总数上限
+const maxDrainBatch = 4096
+
+// runCycle 处理一轮到期任务
+// 回调中添加的任务已完成入队时在同一轮继续排空并处理，已到期的任务无需等待下一次唤醒；
+// 至多重复 maxCycleRounds 次、排空约 maxDrainBatch 个任务，余下的交给 run loop，
+// 避免持续入队时无法响应 Stop 与 inspect
+func (t *Timer) runCycle() {
+	defer t.publishPending()
+
+	drained := 0
+	for round := 0; round < maxCycleRounds; round++ {
+		t.applyHandler()
+		n := t.drainQueue()
+		if n == 0 && round > 0 {
+			// 生产者尚未完成入队链接，由 run loop 让出调度后再处理
+			return
+		}
+		drained += n
+		t.handleExpired()
+		// 取回在到期处理之后：取回时已过期的任务会在 addToWheel 中立即触发，
+		// 先处理时间轮中截止时间更早的任务才能保持触发顺序
+		t.rehydrate()
+		t.maybeCompact()
+		t.maybeTune()
+		t.rearmRefreshed()
+		t.flushExpired()
+		t.firePrecise()
+		t.fireDeferred()
+		t.fireWaiting()
+		t.flushBatch()
+		// 精确触发、限流与并发上限阶段被 WithBeforeFire 推迟的任务同样在本轮放回时间轮
+		t.rearmRefreshed()
+		if t.queue.IsEmpty() || drained >= maxDrainBatch {
+			return
+		}
+
+		// 入队时发出的唤醒信号由本轮排空代替，先消费再排空，之后入队的生产者会重新发出
+		select {
+		case <-t.wakeChan:
+		default:
+		}
+	}
+}
+
+// drainQueue 将队列中的任务放入时间轮，每次至多 maxDrainBatch 个
+// 生产者持续入队时也能及时返回，余下的由调用方下一轮继续处理
+// 在 handler 中经 inspect 重入时不排空，队列正由外层处理
+func (t *Timer) drainQueue() int {
+	if t.reentered {
+		return 0
+	}
+	return t.queue.DrainN(maxDrainBatch, t.admit)
+}
+
+// admit 放入从队列取出的任务并统计延迟分布
+// Refresh、Snooze、否决推迟与取回等直接重新放入时间轮的任务不经过这里，不会使分布偏向重复放入的任务
+func (t *Timer) admit(entry *Entry) {
+	if t.delayStats != nil || t.tuner != nil {
+		delay := t.dueAt(entry).Sub(t.now())
+		if t.delayStats != nil {
+			t.delayStats.record(delay)
+		}
+		if t.tuner != nil {
+			t.tuner.window.record(delay)
+		}
+	}
+	t.addToWheel(entry)
 }
 
 func (t *Timer) addToWheel(entry *Entry) {
-	now := time.Now()
+	now := t.now()
+
+	// 关键任务提前 criticalLead 到期，剩余时间由精确阶段忙等
+	expireAt := entry.expireAt
+	if entry.critical {
+		expireAt = expireAt.Add(-t.criticalLead)
+	}
 
-	if entry.expireAt.Before(now) || entry.expireAt.Equal(now) {
-		t.handler(entry)
+	if !expireAt.After(now) {
+		t.expire(entry)
 		return
 	}
+	if t.spill != nil && t.spillOut(entry, now) {
+		return
+	}
+
+	if !t.addToHeap(entry, now) {
+		t.placeInWheel(entry, expireAt, now)
+	}
+	entry.transit(StateScheduled)
+}
 
+func (t *Timer) placeInWheel(entry *Entry, expireAt, now time.Time) {
 	if t.wheel == nil {
 		t.start = now
-		interval := uint64(entry.expireAt.Sub(now).Milliseconds())
+		t.wheelStart.Store(now.UnixNano())
+		if t.drift != nil {
+			t.drift.resetEpoch(now)
+		}
+	}
+	interval := ceilMs(expireAt.Sub(t.start))
+	if interval >= maxMs[MaxLevel] {
+		// 超出最高层的间隔会被槽位掩码截断而提前触发，改为截断到可表示的最大值
+		interval = maxMs[MaxLevel] - 1
+		requested := entry.expireAt
+		entry.expireAt = t.start.Add(time.Duration(interval) * time.Millisecond)
+		if t.hooks.onClamp != nil {
+			t.enterCallback()
+			t.hooks.onClamp(entry, requested)
+			t.exitCallback()
+		}
+	}
+
+	if t.wheel == nil {
 		t.buildWheelAndAdd(entry, interval)
 	} else {
-		interval := uint64(entry.expireAt.Sub(t.start).Milliseconds())
 		t.levelUpAndAdd(entry, interval)
 	}
 	t.numEntries++
 }
 
+// ceilMs 将时长向上取整为毫秒
+// 到期判断使用向下取整的已流逝毫秒数，放置时向上取整才能保证任务永不提前触发
+func ceilMs(d time.Duration) uint64 {
+	return uint64((d + time.Millisecond - 1) / time.Millisecond)
+}
+
 func (t *Timer) buildWheelAndAdd(entry *Entry, interval uint64) {
-	level := 0
+	level := t.minLevel
 	for level < MaxLevel {
 		if interval < maxMs[level] {
 			break
@@ -168,17 +688,97 @@ func (t *Timer) levelUpAndAdd(entry *Entry, interval uint64) {
 }
 
 func (t *Timer) handleExpired() {
-	if t.wheel == nil || t.numEntries == 0 {
-		return
+	t.handleHeapExpired()
+	if t.wheel != nil && t.numEntries > 0 {
+		now := t.now()
+		interval := uint64(now.Sub(t.start).Milliseconds())
+		if t.drift != nil {
+			t.drift.wheelMs = t.drift.epochMs + interval
+		}
+
+		count := t.wheel.HandleExpiredEntries(t.expire, interval)
+		t.numEntries -= uint64(count)
+
+		t.maintenance(interval)
 	}
+	// 在处理完本轮到期任务之后测量，回调阻塞 run loop 的时长计入时间轮偏差
+	t.checkDrift()
+	t.flushExpired()
+}
 
-	now := time.Now()
-	interval := uint64(now.Sub(t.start).Milliseconds())
+// rearmRefreshed 将被 Refresh 推迟的任务重新放入时间轮
+// 遍历时间轮期间不能修改结构，因此统一在处理完过期任务后执行；重新入轮的任务再次接受 Refresh 与 Snooze
+func (t *Timer) rearmRefreshed() {
+	for i := 0; i < len(t.rearm); i++ {
+		entry := t.rearm[i]
+		t.rearm[i] = nil
+		entry.clearFlag(flagSealed)
+		t.addToWheel(entry)
+	}
+	t.rearm = t.rearm[:0]
+}
 
-	count := t.wheel.HandleExpiredEntries(t.handler, interval)
-	t.numEntries -= uint64(count)
+// dispatch 将到期任务交给 handler，已被 Refresh 或 Snooze 推迟的任务改为重新入轮
+// 判断期间持有推迟标记，并发的 Refresh 与 Snooze 要么在判断前生效，要么在确定触发后返回 false
+func (t *Timer) dispatch(entry *Entry) {
+	entry.holdDefer()
+	if at, ok := entry.takeDeadline(); ok && !entry.IsDone() {
+		entry.expireAt = at
+		entry.endDefer()
+		t.rearm = append(t.rearm, entry)
+		return
+	}
+	entry.seal()
+	if entry.critical && t.holdPrecise(entry) {
+		return
+	}
+	if t.limited(entry) {
+		return
+	}
+	t.fire(entry)
+}
 
-	t.maintenance(interval)
+// fire 将条目交给 handler
+func (t *Timer) fire(entry *Entry) {
+	if t.hooks.beforeFire != nil && t.vetoed(entry) {
+		return
+	}
+	if t.groups != nil && t.throttled(entry) {
+		return
+	}
+	t.deliver(entry)
+}
+
+// deliver 不再检查并发上限，直接将条目交给 handler
+func (t *Timer) deliver(entry *Entry) {
+	if t.leaks != nil {
+		t.leaks.forget(entry)
+	}
+	if entry.detach() {
+		// 已被 Release 的条目直接回收，无需交给 handler
+		entry.tryRecycle()
+		return
+	}
+	t.fired++
+	if t.fireTimestamps || t.hooks.onLate != nil {
+		now := t.now()
+		if t.fireTimestamps {
+			entry.firedAt = now.UnixNano()
+		}
+		if t.hooks.onLate != nil && !entry.IsDone() {
+			if drift := now.Sub(entry.expireAt); drift > t.hooks.lateThreshold {
+				t.enterCallback()
+				t.hooks.onLate(entry, drift)
+				t.exitCallback()
+			}
+		}
+	}
+	if t.batch.handler != nil && t.collect(entry) {
+		return
+	}
+	t.enterCallback()
+	t.route(entry)(entry)
+	t.exitCallback()
 }
 
 func (t *Timer) maintenance(interval uint64) {
@@ -196,24 +796,112 @@ func (t *Timer) maintenance(interval uint64) {
 	if n > 0 {
 		t.wheel.Rotate(n)
 		t.start = t.start.Add(time.Duration(n*t.wheel.MsPerSlot()) * time.Millisecond)
+		t.wheelStart.Store(t.start.UnixNano())
+		if t.drift != nil {
+			t.drift.epochMs += n * t.wheel.MsPerSlot()
+		}
 	}
 
 	t.levelDownIfNeeded()
+	t.maybeReroot()
 }
 
 func (t *Timer) levelDownIfNeeded() {
-	for t.wheel != nil && t.wheel.CanLevelDown() {
+	for t.wheel != nil && t.wheel.CanLevelDown() && t.wheel.Level() > t.minLevel {
 		t.wheel = t.wheel.LevelDown()
 	}
 }
 
+// dropAll 将停止时仍未触发的任务标记为 Dropped
+func (t *Timer) dropAll() {
+	t.drainAll(func(e *Entry) {
+		e.transit(StateDropped)
+		if e.detach() {
+			e.tryRecycle()
+		}
+	})
+}
+
+// drainAll 将时间轮、队列与待重新放入的条目全部交给 fn 并清空
+func (t *Timer) drainAll(fn func(*Entry)) {
+	if t.wheel != nil {
+		t.wheel.Walk(func(e *Entry, _ uint64) bool {
+			fn(e)
+			return true
+		})
+		t.wheel = nil
+		t.numEntries = 0
+	}
+	t.queue.DrainAll(fn)
+	for i, e := range t.heap {
+		fn(e)
+		t.heap[i] = nil
+	}
+	t.heap = t.heap[:0]
+	for i, e := range t.precise {
+		fn(e)
+		t.precise[i] = nil
+	}
+	t.precise = t.precise[:0]
+	for i, e := range t.rearm {
+		fn(e)
+		t.rearm[i] = nil
+	}
+	t.rearm = t.rearm[:0]
+	for i, e := range t.order.expired {
+		fn(e)
+		t.order.expired[i] = nil
+	}
+	t.order.expired = t.order.expired[:0]
+	for i := t.rate.head; i < len(t.rate.deferred); i++ {
+		fn(t.rate.deferred[i])
+		t.rate.deferred[i] = nil
+	}
+	t.rate.deferred = t.rate.deferred[:0]
+	t.rate.head = 0
+	for _, g := range t.groups {
+		for i := g.head; i < len(g.queue); i++ {
+			fn(g.queue[i])
+			g.queue[i] = nil
+		}
+		g.waiting.Add(-int64(len(g.queue) - g.head))
+		g.queue = g.queue[:0]
+		g.head = 0
+	}
+}
+
+// Adopt 接收由其他定时器交出的未触发条目，保留其截止时间、标签与编号 - Wait-Free
+// 条目已结束、已被释放或仍属于某个定时器时返回 false
+func (t *Timer) Adopt(entry *Entry) bool {
+	for {
+		v := entry.state.Load()
+		if v&flagDetached == 0 || v&flagReleased != 0 {
+			return false
+		}
+		if s := EntryState(v & stateMask); s != StateQueued && s != StateScheduled {
+			return false
+		}
+		if entry.state.CompareAndSwap(v, v&^(stateMask|flagDetached|flagPushed)|uint64(StateQueued)) {
+			break
+		}
+	}
+	entry.owner = t
+	t.push(entry)
+	return true
+}
+
 func (t *Timer) calculateNextWake() *time.Time {
+	if len(t.rearm) > 0 || len(t.order.expired) > 0 {
+		// 放回时间轮时已到期、等待稳定顺序交付的任务，下一轮立即处理
+		now := t.now()
+		return &now
+	}
 	if t.wheel == nil || t.numEntries == 0 {
-		return nil
+		return t.nextHeap()
 	}
 
 	nextMs := t.wheel.NextExpirationTime()
-	now := time.Now()
+	now := t.now()
 	interval := uint64(now.Sub(t.start).Milliseconds())
 
 	if nextMs <= interval {
@@ -225,7 +913,22 @@ func (t *Timer) calculateNextWake() *time.Time {
 	return &result
 }
 
-// Pending 返回待处理任务数量
+// Pending 返回待处理任务数量（不含尚在队列中的任务） - O(1)
+// 由 run loop 在每轮结束时发布，读取不等待 run loop，可在 handler 等待的 goroutine 中调用
 func (t *Timer) Pending() uint64 {
-	return t.numEntries
+	return t.pendingN.Load()
+}
+
+// publishPending 发布当前的待处理任务数，仅在 run loop 中或 run loop 停止后调用
+func (t *Timer) publishPending() {
+	t.pendingN.Store(t.pending())
+}
+
+// pending 仅在 run loop 中或 run loop 停止后调用
+func (t *Timer) pending() uint64 {
+	n := t.numEntries + uint64(len(t.heap)) + uint64(len(t.order.expired)) + uint64(len(t.rearm)) + uint64(len(t.rate.deferred)-t.rate.head)
+	for _, g := range t.groups {
+		n += uint64(len(g.queue) - g.head)
+	}
+	return n
 }
@@ -1,10 +1,14 @@
 package whTimer
 
 import (
+	"errors"
+	"runtime"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+	"unsafe"
 )
 
 func TestWheelBasic(t *testing.T) {
@@ -203,6 +207,311 @@ func TestTimerCancel(t *testing.T) {
 	}
 }
 
+func TestTimerAddEntries(t *testing.T) {
+	var executed atomic.Int32
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	})
+	timer.Start()
+	defer timer.Stop()
+
+	callbacks := make([]func(), 5)
+	for i := range callbacks {
+		callbacks[i] = func() {
+			executed.Add(1)
+		}
+	}
+	entries := timer.AddEntries(20*time.Millisecond, callbacks...)
+	if len(entries) != 5 {
+		t.Fatalf("expected 5 entries, got %d", len(entries))
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if executed.Load() != 5 {
+		t.Errorf("expected 5 executions, got %d", executed.Load())
+	}
+}
+
+func TestEntryState(t *testing.T) {
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	})
+
+	fired := timer.AddEntry(20*time.Millisecond, func() {}).Ref()
+	canceled := timer.AddEntry(20*time.Millisecond, func() {})
+	pending := timer.AddEntry(time.Hour, func() {})
+
+	if fired.State() != StateQueued {
+		t.Errorf("expected Queued, got %s", fired.State())
+	}
+	if !canceled.Cancel() {
+		t.Error("cancel before firing should succeed")
+	}
+
+	timer.Start()
+	time.Sleep(60 * time.Millisecond)
+
+	if fired.State() != StateFired {
+		t.Errorf("expected Fired, got %s", fired.State())
+	}
+	if fired.Cancel() {
+		t.Error("cancel after firing should fail")
+	}
+	if canceled.State() != StateCanceled {
+		t.Errorf("expected Canceled, got %s", canceled.State())
+	}
+	if pending.State() != StateScheduled {
+		t.Errorf("expected Scheduled, got %s", pending.State())
+	}
+
+	timer.Stop()
+	if pending.State() != StateDropped {
+		t.Errorf("expected Dropped, got %s", pending.State())
+	}
+}
+
+func TestEntryRefresh(t *testing.T) {
+	var executed atomic.Int32
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	})
+	timer.Start()
+	defer timer.Stop()
+
+	entry := timer.AddEntry(50*time.Millisecond, func() {
+		executed.Add(1)
+	}).Ref()
+
+	// 持续刷新，任务不应触发
+	for i := 0; i < 5; i++ {
+		time.Sleep(20 * time.Millisecond)
+		entry.Refresh()
+	}
+	if executed.Load() != 0 {
+		t.Errorf("expected 0 execution while refreshing, got %d", executed.Load())
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if executed.Load() != 1 {
+		t.Errorf("expected 1 execution after idle, got %d", executed.Load())
+	}
+	if entry.Refresh() {
+		t.Error("refresh after firing should fail")
+	}
+}
+
+func TestEntryRecycle(t *testing.T) {
+	// 调用方持有 *Entry：执行后不自动回收，旧指针上的 Cancel 不会作用到其他任务
+	entry := NewEntry(time.Now(), func() {})
+	entry.detach()
+	entry.Execute()
+	if entry.state.Load()&flagPooled != 0 {
+		t.Fatal("entry with a raw handle was recycled without Release")
+	}
+	if entry.Cancel() || entry.State() != StateFired {
+		t.Fatalf("stale Cancel changed a fired entry to %s", entry.State())
+	}
+
+	// 只以 EntryRef 交出：仍被定时器引用时不回收
+	entry = NewEntry(time.Now(), func() {})
+	entry.hide()
+	ref := entry.Ref()
+	entry.Execute()
+	if ref.State() != StateFired || entry.state.Load()&flagPooled != 0 {
+		t.Fatalf("expected Fired and not pooled before detach, got %s", ref.State())
+	}
+	entry.detach()
+	entry.tryRecycle()
+	if entry.state.Load()&flagPooled == 0 {
+		t.Fatal("ref-only entry was not recycled after execution")
+	}
+
+	// 强制复用同一对象，与对象池是否命中无关
+	var ran bool
+	entry.reset(time.Now().Add(time.Hour), func() { ran = true })
+	if ref.Valid() {
+		t.Error("ref should be invalid after reuse")
+	}
+	if ref.Cancel() || ref.Refresh() {
+		t.Error("stale ref should not touch the reused entry")
+	}
+	if ref.State() != StateRecycled {
+		t.Errorf("expected Recycled, got %s", ref.State())
+	}
+	if entry.State() != StateQueued {
+		t.Fatalf("reused entry state = %s, want Queued", entry.State())
+	}
+	entry.Execute()
+	if !ran {
+		t.Fatal("reused entry did not run after a stale Cancel")
+	}
+}
+
+func TestTimerWithoutPooling(t *testing.T) {
+	var executed atomic.Int32
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	}, WithPooling(false))
+	timer.Start()
+	defer timer.Stop()
+
+	entry := timer.AddEntry(10*time.Millisecond, func() {
+		executed.Add(1)
+	})
+	time.Sleep(50 * time.Millisecond)
+
+	if executed.Load() != 1 {
+		t.Errorf("expected 1 execution, got %d", executed.Load())
+	}
+	// 未池化的条目执行后保持原状态，不会被复用
+	if entry.State() != StateFired || !entry.Ref().Valid() {
+		t.Errorf("expected unpooled entry to stay Fired, got %s", entry.State())
+	}
+}
+
+type countRunnable struct {
+	n atomic.Int32
+}
+
+func (r *countRunnable) Run() {
+	r.n.Add(1)
+}
+
+func TestAddRunnableZeroAlloc(t *testing.T) {
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	})
+	r := &countRunnable{}
+
+	// AllocsPerRun 会将 GOMAXPROCS 设为 1，提前设置以免对象池的 per-P 缓存被重建
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	// 预热对象池
+	warm := make([]*Entry, 200)
+	for i := range warm {
+		warm[i] = NewEntry(time.Now(), nil)
+	}
+	for _, e := range warm {
+		e.detach()
+		e.Release()
+	}
+
+	allocs := testing.AllocsPerRun(50, func() {
+		timer.AddRunnable(time.Hour, r)
+	})
+	if allocs != 0 {
+		t.Errorf("expected 0 allocs per AddRunnable, got %.1f", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(50, func() {
+		timer.AddRunnableRef(time.Hour, r)
+	})
+	if allocs != 0 {
+		t.Errorf("expected 0 allocs per AddRunnableRef, got %.1f", allocs)
+	}
+
+	timer.Start()
+	defer timer.Stop()
+	timer.AddRunnable(10*time.Millisecond, r)
+	ref := timer.AddRunnableRef(10*time.Millisecond, r)
+	time.Sleep(50 * time.Millisecond)
+	if r.n.Load() != 2 {
+		t.Errorf("expected 2 runs, got %d", r.n.Load())
+	}
+	if s := ref.State(); s != StateFired && s != StateRecycled {
+		t.Errorf("ref-only entry state = %s after firing", s)
+	}
+}
+
+func TestTimerOnDemand(t *testing.T) {
+	var executed atomic.Int32
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	}, WithOnDemand(true))
+	timer.Start()
+	defer timer.Stop()
+
+	time.Sleep(10 * time.Millisecond)
+	if timer.loopActive.Load() {
+		t.Error("run loop should park when idle")
+	}
+
+	for i := 0; i < 3; i++ {
+		timer.AddEntry(10*time.Millisecond, func() {
+			executed.Add(1)
+		})
+		if infos := timer.Upcoming(1); len(infos) != 1 {
+			t.Errorf("expected 1 upcoming entry, got %d", len(infos))
+		}
+		time.Sleep(40 * time.Millisecond)
+		if timer.loopActive.Load() {
+			t.Error("run loop should park again after firing")
+		}
+	}
+
+	if executed.Load() != 3 {
+		t.Errorf("expected 3 executions, got %d", executed.Load())
+	}
+}
+
+func TestTimerLockOSThread(t *testing.T) {
+	var executed atomic.Int32
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	}, WithCPUAffinity(0))
+	timer.Start()
+	defer timer.Stop()
+
+	timer.AddEntry(10*time.Millisecond, func() {
+		executed.Add(1)
+	})
+	time.Sleep(50 * time.Millisecond)
+
+	if executed.Load() != 1 {
+		t.Errorf("expected 1 execution, got %d", executed.Load())
+	}
+	if err := timer.CPUAffinityErr(); (err == nil) != (runtime.GOOS == "linux") {
+		t.Errorf("CPUAffinityErr = %v on %s", err, runtime.GOOS)
+	}
+
+	// 不存在的 CPU：绑定失败时报告错误，run loop 照常运行
+	bad := NewTimer(func(e *Entry) { e.Execute() }, WithCPUAffinity(-1))
+	bad.Start()
+	defer bad.Stop()
+	bad.AddEntry(time.Millisecond, func() { executed.Add(1) })
+	time.Sleep(30 * time.Millisecond)
+	if bad.CPUAffinityErr() == nil || executed.Load() != 2 {
+		t.Errorf("invalid affinity: err=%v executed=%d", bad.CPUAffinityErr(), executed.Load())
+	}
+}
+
+func TestTimerNeverFiresEarly(t *testing.T) {
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	})
+	timer.Start()
+	defer timer.Stop()
+
+	var wg sync.WaitGroup
+	var early atomic.Int32
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		expireAt := time.Now().Add(time.Duration(i) * 337 * time.Microsecond)
+		timer.AddEntryAt(expireAt, func() {
+			if time.Now().Before(expireAt) {
+				early.Add(1)
+			}
+			wg.Done()
+		})
+	}
+	wg.Wait()
+
+	if early.Load() != 0 {
+		t.Errorf("%d entries fired before their deadline", early.Load())
+	}
+}
+
 func TestTimerConcurrentAdd(t *testing.T) {
 	var executed atomic.Int64
 	handler := func(e *Entry) {
@@ -237,6 +546,26 @@ func TestTimerConcurrentAdd(t *testing.T) {
 	}
 }
 
+func TestTimerLayout(t *testing.T) {
+	if cacheLineSize < 64 {
+		t.Skip("cache line padding disabled in tiny profile")
+	}
+	var timer Timer
+	sleepUntil := unsafe.Offsetof(timer.sleepUntil)
+	running := unsafe.Offsetof(timer.running)
+	wheel := unsafe.Offsetof(timer.wheel)
+
+	if sleepUntil-unsafe.Offsetof(timer.noPooling) < cacheLineSize {
+		t.Error("sleepUntil shares a cache line with producer read-only fields")
+	}
+	if running-sleepUntil < cacheLineSize {
+		t.Error("running shares a cache line with sleepUntil")
+	}
+	if wheel-running < cacheLineSize {
+		t.Error("run loop state shares a cache line with running")
+	}
+}
+
 // Benchmark tests
 
 func BenchmarkWheelAddEntry(b *testing.B) {
@@ -272,6 +601,21 @@ func BenchmarkTimerAdd(b *testing.B) {
 	}
 }
 
+func BenchmarkTimerAddRunnable(b *testing.B) {
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	})
+	timer.Start()
+	defer timer.Stop()
+	r := &countRunnable{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		timer.AddRunnable(0, r)
+	}
+}
+
 func BenchmarkTimerAddParallel(b *testing.B) {
 	handler := func(e *Entry) {}
 
@@ -289,6 +633,53 @@ func BenchmarkTimerAddParallel(b *testing.B) {
 	})
 }
 
+// run loop 持续处理到期任务时的并发添加，用于观察字段伪共享
+func BenchmarkTimerAddParallelBusy(b *testing.B) {
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	})
+	timer.Start()
+	defer timer.Stop()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			timer.AddEntry(time.Duration(i%4)*time.Millisecond, func() {})
+			i++
+		}
+	})
+}
+
+// 测量触发延迟（实际触发时间 - 计划时间），用于评估各平台可达到的精度
+// Windows 下可对比 WithHighResolution 开启前后的 late-ns/op
+func BenchmarkTimerFireLatency(b *testing.B) {
+	for _, highRes := range []bool{false, true} {
+		name := "default"
+		if highRes {
+			name = "highres"
+		}
+		b.Run(name, func(b *testing.B) {
+			timer := NewTimer(func(e *Entry) {
+				e.Execute()
+			}, WithHighResolution(highRes))
+			timer.Start()
+			defer timer.Stop()
+
+			var total time.Duration
+			done := make(chan time.Duration, 1)
+			for i := 0; i < b.N; i++ {
+				expireAt := time.Now().Add(2 * time.Millisecond)
+				timer.AddEntryAt(expireAt, func() {
+					done <- time.Since(expireAt)
+				})
+				total += <-done
+			}
+			b.ReportMetric(float64(total.Nanoseconds())/float64(b.N), "late-ns/op")
+		})
+	}
+}
+
 func BenchmarkNextExpirationTime(b *testing.B) {
 	w := NewWheel(2)
 
@@ -322,3 +713,302 @@ func BenchmarkHandleExpired(b *testing.B) {
 		w.HandleExpiredEntries(handler, 64)
 	}
 }
+
+func TestTimerStopAndDetach(t *testing.T) {
+	src := NewTimer(func(e *Entry) { e.Execute() })
+	src.Start()
+
+	var fired atomic.Int32
+	for i := 0; i < 5; i++ {
+		src.AddTaggedEntry("conn", time.Duration(50+i)*time.Millisecond, func() { fired.Add(1) })
+	}
+	src.AddEntry(time.Hour, func() {}).Cancel()
+	time.Sleep(5 * time.Millisecond)
+
+	entries := src.StopAndDetach()
+	if len(entries) != 5 {
+		t.Fatalf("detached %d entries, want 5", len(entries))
+	}
+
+	dst := NewTimer(func(e *Entry) { e.Execute() })
+	dst.Start()
+	defer dst.Stop()
+	for _, e := range entries {
+		if e.Tag() != "conn" {
+			t.Fatalf("tag=%q", e.Tag())
+		}
+		if !dst.Adopt(e) {
+			t.Fatal("Adopt failed")
+		}
+	}
+	if dst.Adopt(entries[0]) {
+		t.Fatal("Adopt of owned entry should fail")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for fired.Load() != 5 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if fired.Load() != 5 {
+		t.Fatalf("fired=%d, want 5", fired.Load())
+	}
+}
+
+func TestEntryLateness(t *testing.T) {
+	var lateness []time.Duration
+	timer := NewTimer(func(e *Entry) {
+		lateness = append(lateness, e.Lateness())
+		e.Execute()
+	}, WithFireTimestamps(true))
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	timer.AddEntry(100*time.Millisecond, func() {})
+	timer.AddEntry(250*time.Millisecond, func() {})
+	timer.Advance(base.Add(300 * time.Millisecond))
+	if !slices.Equal(lateness, []time.Duration{200 * time.Millisecond, 50 * time.Millisecond}) {
+		t.Fatalf("lateness=%v", lateness)
+	}
+
+	if err := timer.Reconfigure(WithFireTimestamps(false)); err != nil {
+		t.Fatal(err)
+	}
+	timer.AddEntry(100*time.Millisecond, func() {})
+	timer.Advance(base.Add(time.Second))
+	if lateness[2] != 0 {
+		t.Fatalf("lateness recorded after disabling: %v", lateness[2])
+	}
+}
+
+func TestTimerClampMaxDuration(t *testing.T) {
+	var clamped []time.Time
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithOnClamp(func(e *Entry, requested time.Time) {
+		clamped = append(clamped, requested)
+	}))
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	if _, err := timer.TryAddEntry(MaxDuration, func() {}); !errors.Is(err, ErrDelayTooLong) {
+		t.Fatalf("err=%v", err)
+	}
+
+	var fired atomic.Bool
+	e := timer.AddEntry(MaxDuration+time.Hour, func() { fired.Store(true) })
+	timer.Advance(base)
+	timer.Advance(base.Add(2 * time.Hour))
+	if fired.Load() {
+		t.Fatal("entry beyond MaxDuration fired early")
+	}
+	if len(clamped) != 1 || !clamped[0].Equal(base.Add(MaxDuration+time.Hour)) || !e.ExpireAt().Before(clamped[0]) {
+		t.Fatalf("clamped=%v expireAt=%v", clamped, e.ExpireAt())
+	}
+
+	// 时间轮起点仍为 base：一年后距 now 不足 MaxDuration 的延迟也会超出时间轮范围，TryAddEntry 应拒绝而非截断
+	later := base.Add(365 * 24 * time.Hour)
+	timer.Advance(later)
+	if _, err := timer.TryAddEntry(MaxDuration-24*time.Hour, func() {}); !errors.Is(err, ErrDelayTooLong) {
+		t.Fatalf("TryAddEntry beyond wheel range: err=%v", err)
+	}
+	if _, err := timer.TryAddEntryAt(later.Add(time.Hour), func() {}); err != nil {
+		t.Fatal(err)
+	}
+	timer.AddEntry(MaxDuration-24*time.Hour, func() {})
+	timer.Advance(later)
+	if len(clamped) != 2 {
+		t.Fatalf("AddEntry within MaxDuration of now was not clamped: %v", clamped)
+	}
+}
+
+func TestTimerTryAdd(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+
+	cases := []struct {
+		delay    time.Duration
+		callback func()
+		want     error
+	}{
+		{time.Second, nil, ErrNilCallback},
+		{0, func() {}, ErrInvalidDelay},
+		{-time.Second, func() {}, ErrInvalidDelay},
+		{MaxDuration, func() {}, ErrDelayTooLong},
+		{time.Second, func() {}, nil},
+	}
+	for _, c := range cases {
+		e, err := timer.TryAddEntry(c.delay, c.callback)
+		if !errors.Is(err, c.want) || (err == nil) != (e != nil) {
+			t.Errorf("TryAddEntry(%v): entry=%v err=%v, want %v", c.delay, e != nil, err, c.want)
+		}
+	}
+	if _, err := timer.TryAddEntryAt(time.Now().Add(-time.Second), func() {}); !errors.Is(err, ErrInvalidDelay) {
+		t.Errorf("TryAddEntryAt in the past: err=%v", err)
+	}
+
+	timer.Stop()
+	if _, err := timer.TryAddEntry(time.Second, func() {}); !errors.Is(err, ErrTimerStopped) {
+		t.Errorf("TryAddEntry after Stop: err=%v", err)
+	}
+}
+
+func TestEntrySnooze(t *testing.T) {
+	var fired []time.Time
+	timer := NewTimer(func(e *Entry) {
+		fired = append(fired, e.FiredAt())
+		e.Execute()
+	}, WithFireTimestamps(true))
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	e := timer.AddEntry(100*time.Millisecond, func() {})
+	timer.Advance(base)
+	e.Snooze(100 * time.Millisecond)
+	e.Snooze(50 * time.Millisecond)
+	if e.Snooze(0) {
+		t.Fatal("non-positive snooze accepted")
+	}
+
+	timer.Advance(base.Add(200 * time.Millisecond))
+	if len(fired) != 0 {
+		t.Fatalf("fired before snoozed deadline: %v", fired)
+	}
+	timer.Advance(base.Add(250 * time.Millisecond))
+	if len(fired) != 1 || !fired[0].Equal(base.Add(250*time.Millisecond)) {
+		t.Fatalf("fired=%v", fired)
+	}
+	if e.Snooze(time.Second) {
+		t.Fatal("snooze after fire accepted")
+	}
+}
+
+func TestEntrySnoozeVisible(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	a := timer.AddEntry(100*time.Millisecond, func() {})
+	b := timer.AddEntry(200*time.Millisecond, func() {})
+	timer.Advance(base)
+	a.Snooze(time.Second)
+	if !a.ExpireAt().Equal(base.Add(1100 * time.Millisecond)) {
+		t.Fatalf("ExpireAt=%v", a.ExpireAt())
+	}
+	// a 仍在 100ms 的槽位，但实际排在 b 之后
+	if up := timer.Upcoming(1); len(up) != 1 || up[0].ID != b.ID() {
+		t.Fatalf("upcoming=%+v", up)
+	}
+	if d := timer.Dump(); len(d.Entries) != 2 || d.Entries[1].ID != a.ID() || !d.Entries[1].Deadline.Equal(a.ExpireAt()) {
+		t.Fatalf("dump=%+v", d.Entries)
+	}
+}
+
+func TestEntrySnoozeConcurrent(t *testing.T) {
+	var firedAt atomic.Int64
+	timer := NewTimer(func(e *Entry) {
+		firedAt.Store(e.ExpireAt().UnixNano())
+		e.Execute()
+	})
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	e := timer.AddEntry(10*time.Millisecond, func() {})
+	timer.Advance(base)
+
+	// 返回 true 的每次推迟都必须计入触发时的截止时间
+	var accepted atomic.Int64
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for accepted.Load() < 1000 && e.Snooze(time.Millisecond) {
+			accepted.Add(1)
+		}
+	}()
+	for now := base; firedAt.Load() == 0; {
+		now = now.Add(time.Millisecond)
+		timer.Advance(now)
+	}
+	<-done
+	if want := base.Add(10*time.Millisecond + time.Duration(accepted.Load())*time.Millisecond); firedAt.Load() != want.UnixNano() {
+		t.Fatalf("fired at %v, want %v after %d snoozes", time.Unix(0, firedAt.Load()), want, accepted.Load())
+	}
+}
+
+func TestTimerOnLate(t *testing.T) {
+	var late []time.Duration
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithOnLate(100*time.Millisecond, func(e *Entry, drift time.Duration) {
+		late = append(late, drift)
+	}))
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	timer.AddEntry(100*time.Millisecond, func() {})
+	timer.AddEntry(300*time.Millisecond, func() {})
+	timer.AddEntry(50*time.Millisecond, func() {}).Cancel()
+	timer.Advance(base.Add(350 * time.Millisecond))
+	if !slices.Equal(late, []time.Duration{250 * time.Millisecond}) {
+		t.Fatalf("late=%v", late)
+	}
+}
+
+func TestTimerCallbackAddsDueEntry(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	const depth = 1000
+	done := make(chan struct{})
+	var step func(n int)
+	step = func(n int) {
+		if n == depth {
+			close(done)
+			return
+		}
+		timer.AddEntry(0, func() { step(n + 1) })
+	}
+	timer.AddEntry(time.Millisecond, func() { step(0) })
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("chained entries did not fire")
+	}
+	if p := timer.Pending(); p != 0 {
+		t.Fatalf("pending=%d", p)
+	}
+}
+
+func TestTimerPreallocate(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	timer.Preallocate(128)
+	if idle := timer.Stats().PoolIdle; idle < 64 {
+		t.Fatalf("PoolIdle=%d after Preallocate", idle)
+	}
+
+	unpooled := NewTimer(func(e *Entry) { e.Execute() }, WithPooling(false))
+	before := poolCounters.puts.Load()
+	unpooled.Preallocate(128)
+	if poolCounters.puts.Load() != before {
+		t.Fatal("Preallocate filled the pool for an unpooled timer")
+	}
+}
+
+func TestEntryRefreshManualClock(t *testing.T) {
+	timer := NewTimer(func(e *Entry) { e.Execute() })
+	base := time.Unix(1000, 0)
+	timer.Advance(base)
+
+	// Refresh 以所属定时器的时间计算新的截止时间
+	var fired int
+	e := timer.AddEntry(10*time.Millisecond, func() { fired++ })
+	timer.Advance(base.Add(5 * time.Millisecond))
+	if !e.Refresh() {
+		t.Fatal("Refresh of a pending entry failed")
+	}
+	timer.Advance(base.Add(12 * time.Millisecond))
+	if fired != 0 {
+		t.Fatal("refreshed entry fired at its original deadline")
+	}
+	timer.Advance(base.Add(16 * time.Millisecond))
+	if fired != 1 {
+		t.Fatalf("refreshed entry fired %d times by 16ms, want 1", fired)
+	}
+}
@@ -0,0 +1,50 @@
+package whTimer
+
+import (
+	"time"
+)
+
+// WithBeforeFire 在到期任务交给 handler 之前调用 fn，返回 false 时本次不执行，可经 Reconfigure 热更新
+// retry > 0 时被否决的任务推迟 retry 后再次检查；否则跳过：重复任务跳到下一个周期，其余任务被取消
+// fn 在 run loop 中调用，不应阻塞，适合功能开关与维护窗口等检查
+func WithBeforeFire(fn func(entry *Entry) bool, retry time.Duration) Option {
+	return func(t *Timer) {
+		t.hooks.beforeFire = fn
+		t.hooks.vetoRetry = retry
+		t.hotOption++
+	}
+}
+
+// vetoed 检查到期任务是否被 BeforeFire 否决，否决时按配置推迟、跳过或取消
+func (t *Timer) vetoed(entry *Entry) bool {
+	if entry.IsDone() {
+		return false
+	}
+	t.enterCallback()
+	ok := t.hooks.beforeFire(entry)
+	t.exitCallback()
+	if ok {
+		return false
+	}
+	switch {
+	case t.hooks.vetoRetry > 0:
+		entry.expireAt = t.now().Add(t.hooks.vetoRetry)
+		t.rearm = append(t.rearm, entry)
+	case entry.repeats != 0:
+		if entry.repeats > 0 {
+			entry.repeats--
+		}
+		entry.expireAt = entry.expireAt.Add(entry.interval)
+		t.rearm = append(t.rearm, entry)
+	default:
+		// 与 handler 执行已取消的条目相同：结束并回收，不经过 handler
+		entry.transit(StateCanceled)
+		entry.autoRelease()
+		if t.leaks != nil {
+			t.leaks.forget(entry)
+		}
+		entry.detach()
+		entry.tryRecycle()
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package whTimer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimerBeforeFire(t *testing.T) {
+	base := time.Unix(1000, 0)
+	blackout := true
+	gate := func(e *Entry) bool { return !blackout }
+
+	// 未设置重试：普通任务被取消，重复任务跳到下一个周期
+	timer := NewTimer(func(e *Entry) { e.Execute() }, WithBeforeFire(gate, 0))
+	timer.Advance(base)
+	var once, ticks int
+	ref := timer.AddEntry(10*time.Millisecond, func() { once++ }).Ref()
+	timer.AddRepeating(10*time.Millisecond, 10*time.Millisecond, 3, func() { ticks++ })
+	timer.Advance(base.Add(10 * time.Millisecond))
+	if once != 0 || ticks != 0 || ref.State() != StateCanceled {
+		t.Fatalf("vetoed entries ran: once=%d ticks=%d state=%v", once, ticks, ref.State())
+	}
+	blackout = false
+	timer.Advance(base.Add(40 * time.Millisecond))
+	if ticks != 2 {
+		t.Fatalf("repeating entry ran %d times after skip, want 2", ticks)
+	}
+
+	// 设置重试：被否决的任务推迟后再次检查
+	blackout = true
+	timer = NewTimer(func(e *Entry) { e.Execute() }, WithBeforeFire(gate, 5*time.Millisecond))
+	timer.Advance(base)
+	var fired int
+	timer.AddEntry(10*time.Millisecond, func() { fired++ })
+	timer.Advance(base.Add(12 * time.Millisecond)) // 12ms 被否决，17ms 再次检查
+	blackout = false
+	timer.Advance(base.Add(16 * time.Millisecond))
+	if fired != 0 {
+		t.Fatal("vetoed entry fired before the retry delay")
+	}
+	timer.Advance(base.Add(17 * time.Millisecond))
+	if fired != 1 {
+		t.Fatalf("retried entry fired %d times, want 1", fired)
+	}
+}
+
+func TestTimerBeforeFireLateStages(t *testing.T) {
+	// 关键任务在精确阶段、超出突发的任务在限流阶段被否决；没有其他任务唤醒时，
+	// 推迟的任务须由 run loop 自行放回时间轮
+	for _, critical := range []bool{true, false} {
+		var blackout atomic.Bool
+		blackout.Store(true)
+		var heldID atomic.Uint64
+		fired := make(chan string, 2)
+		timer := NewTimer(func(e *Entry) { e.Execute() },
+			WithBeforeFire(func(e *Entry) bool { return !blackout.Load() || (e.tag != "held" && e.id != heldID.Load()) }, 5*time.Millisecond),
+			WithCriticalLead(2*time.Millisecond),
+			WithRateLimit(100, 1))
+		timer.Start()
+
+		if critical {
+			heldID.Store(timer.AddCritical(5*time.Millisecond, func() { fired <- "held" }).ID())
+		} else {
+			timer.AddEntry(5*time.Millisecond, func() { fired <- "free" })
+			timer.AddTaggedEntry("held", 5*time.Millisecond, func() { fired <- "held" })
+			if name := <-fired; name != "free" {
+				t.Fatalf("first fired %q, want free", name)
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		blackout.Store(false)
+
+		select {
+		case <-fired:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("critical=%v: vetoed entry stranded", critical)
+		}
+		timer.Stop()
+	}
+}
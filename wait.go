@@ -0,0 +1,199 @@
+package whTimer
+
+import (
+	"runtime"
+	"time"
+)
+
+// WaitStrategy run loop 等待下一个过期时间的策略
+// 用于在触发延迟与 CPU 占用之间取舍，无需修改 run loop
+type WaitStrategy interface {
+	// Wait 等待到 deadline 或出现唤醒事件，返回 false 表示定时器已停止
+	Wait(w *Waiter, deadline time.Time) bool
+}
+
+// Waiter 提供给 WaitStrategy 的等待原语
+type Waiter struct {
+	t      *Timer
+	timer  *time.Timer
+	ticker *time.Ticker // Tick 按需创建
+	period time.Duration
+}
+
+func newWaiter(t *Timer) *Waiter {
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	return &Waiter{t: t, timer: timer}
+}
+
+// Sleep 通过运行时定时器阻塞到 deadline，期间响应唤醒、inspect 与停止请求
+func (w *Waiter) Sleep(deadline time.Time) bool {
+	d := time.Until(deadline)
+	if d <= 0 {
+		return true
+	}
+	w.timer.Reset(d)
+
+	select {
+	case <-w.t.stopChan:
+		w.stopTimer()
+		return false
+	case <-w.timer.C:
+	case <-w.t.wakeChan:
+		w.stopTimer()
+	case fn := <-w.t.inspectChan:
+		w.stopTimer()
+		fn()
+	}
+	return true
+}
+
+// SleepOn 阻塞到 ready 可读，期间响应唤醒、inspect 与停止请求
+// 供基于外部事件源（如 timerfd）的等待策略使用
+func (w *Waiter) SleepOn(ready <-chan struct{}) bool {
+	select {
+	case <-w.t.stopChan:
+		return false
+	case <-ready:
+	case <-w.t.wakeChan:
+	case fn := <-w.t.inspectChan:
+		fn()
+	}
+	return true
+}
+
+// Poll 非阻塞检查事件
+// woke 表示出现唤醒或 inspect 请求，run loop 需要重新计算；ok 为 false 表示定时器已停止
+func (w *Waiter) Poll() (woke, ok bool) {
+	select {
+	case <-w.t.stopChan:
+		return false, false
+	case <-w.t.wakeChan:
+		return true, true
+	case fn := <-w.t.inspectChan:
+		fn()
+		return true, true
+	default:
+		return false, true
+	}
+}
+
+// Tick 阻塞到固定周期 ticker 的下一次触发，期间响应 inspect 与停止请求，不响应唤醒
+// ticker 在首次调用时创建，周期变化时重建
+func (w *Waiter) Tick(period time.Duration) bool {
+	if period <= 0 {
+		period = time.Millisecond
+	}
+	if w.ticker == nil {
+		w.ticker = time.NewTicker(period)
+		w.period = period
+	} else if w.period != period {
+		w.ticker.Reset(period)
+		w.period = period
+	}
+
+	select {
+	case <-w.t.stopChan:
+		return false
+	case <-w.ticker.C:
+	case fn := <-w.t.inspectChan:
+		fn()
+	}
+	return true
+}
+
+// release run loop 退出时停止等待原语
+func (w *Waiter) release() {
+	w.stopTimer()
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+}
+
+func (w *Waiter) stopTimer() {
+	if !w.timer.Stop() {
+		select {
+		case <-w.timer.C:
+		default:
+		}
+	}
+}
+
+// spin 忙等到 deadline，期间通过 Poll 响应事件
+func (w *Waiter) spin(deadline time.Time) bool {
+	for time.Now().Before(deadline) {
+		woke, ok := w.Poll()
+		if !ok {
+			return false
+		}
+		if woke {
+			return true
+		}
+		runtime.Gosched()
+	}
+	return true
+}
+
+// SleepWait 始终通过运行时定时器睡眠，默认策略
+type SleepWait struct{}
+
+// Wait 实现 WaitStrategy
+func (SleepWait) Wait(w *Waiter, deadline time.Time) bool {
+	return w.Sleep(deadline)
+}
+
+// YieldWait 先让出调度 Yields 次，仍未到期再睡眠
+// 适合过期时间密集、短暂让出即可等到的场景
+type YieldWait struct {
+	Yields int
+}
+
+// Wait 实现 WaitStrategy
+func (s YieldWait) Wait(w *Waiter, deadline time.Time) bool {
+	for i := 0; i < s.Yields && time.Now().Before(deadline); i++ {
+		woke, ok := w.Poll()
+		if !ok {
+			return false
+		}
+		if woke {
+			return true
+		}
+		runtime.Gosched()
+	}
+	return w.Sleep(deadline)
+}
+
+// SpinWait 始终忙等，触发延迟最低，但会持续占用一个 CPU 核心
+type SpinWait struct{}
+
+// Wait 实现 WaitStrategy
+func (SpinWait) Wait(w *Waiter, deadline time.Time) bool {
+	return w.spin(deadline)
+}
+
+// HybridWait 距离过期超过 SpinThreshold 时睡眠，进入阈值后忙等
+// 兼顾 CPU 占用与 100µs 以下的触发抖动
+type HybridWait struct {
+	SpinThreshold time.Duration
+}
+
+// Wait 实现 WaitStrategy
+func (s HybridWait) Wait(w *Waiter, deadline time.Time) bool {
+	spinFrom := deadline.Add(-s.SpinThreshold)
+	if time.Now().Before(spinFrom) {
+		return w.Sleep(spinFrom)
+	}
+	return w.spin(deadline)
+}
+
+// TickWait 由固定周期的内部 ticker 驱动 run loop，而不是睡眠到下一个过期时间
+// 有待触发任务时每个周期醒来一次，期间添加的任务不唤醒 run loop，留到下一个周期处理；
+// CPU 占用可预测、漂移行为简单，适合任务密集的场景，触发延迟最多一个周期。Period <= 0 时为 1ms（一个槽位）
+type TickWait struct {
+	Period time.Duration
+}
+
+// Wait 实现 WaitStrategy
+func (s TickWait) Wait(w *Waiter, _ time.Time) bool {
+	return w.Tick(s.Period)
+}
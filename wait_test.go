@@ -0,0 +1,63 @@
+package whTimer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimerBusyPoll(t *testing.T) {
+	var late atomic.Int64
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	}, WithBusyPoll(5*time.Millisecond))
+	timer.Start()
+	defer timer.Stop()
+
+	done := make(chan struct{})
+	expireAt := time.Now().Add(20 * time.Millisecond)
+	timer.AddEntryAt(expireAt, func() {
+		late.Store(int64(time.Since(expireAt)))
+		close(done)
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("entry did not fire")
+	}
+	if d := time.Duration(late.Load()); d > 10*time.Millisecond {
+		t.Errorf("busy-poll entry fired %v late", d)
+	}
+}
+
+func TestTimerWaitStrategies(t *testing.T) {
+	strategies := map[string]WaitStrategy{
+		"sleep":  SleepWait{},
+		"yield":  YieldWait{Yields: 10},
+		"spin":   SpinWait{},
+		"hybrid": HybridWait{SpinThreshold: 2 * time.Millisecond},
+		"tick":   TickWait{Period: time.Millisecond},
+	}
+	for name, s := range strategies {
+		t.Run(name, func(t *testing.T) {
+			var executed atomic.Int32
+			timer := NewTimer(func(e *Entry) {
+				e.Execute()
+			}, WithWaitStrategy(s))
+			timer.Start()
+			defer timer.Stop()
+
+			for i := 0; i < 5; i++ {
+				timer.AddEntry(time.Duration(5+i*5)*time.Millisecond, func() {
+					executed.Add(1)
+				})
+			}
+			time.Sleep(80 * time.Millisecond)
+
+			if executed.Load() != 5 {
+				t.Errorf("expected 5 executions, got %d", executed.Load())
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+//go:build linux
+
+package whTimer
+
+import (
+	"os"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// TimerfdWait 基于 Linux timerfd 的等待策略
+// 由内核 timerfd 而不是 Go 运行时定时器负责唤醒，唤醒精度更高；
+// Fd 可交给 epoll 风格的事件循环统一等待
+type TimerfdWait struct {
+	fd    int
+	file  *os.File
+	fired chan struct{}
+}
+
+type itimerspec struct {
+	interval syscall.Timespec
+	value    syscall.Timespec
+}
+
+// NewTimerfdWait 创建 timerfd 等待策略，不再使用时需调用 Close
+func NewTimerfdWait() (*TimerfdWait, error) {
+	const clockMonotonic = 1
+	fd, _, errno := syscall.Syscall(syscall.SYS_TIMERFD_CREATE, clockMonotonic,
+		syscall.O_NONBLOCK|syscall.O_CLOEXEC, 0)
+	if errno != 0 {
+		return nil, os.NewSyscallError("timerfd_create", errno)
+	}
+
+	s := &TimerfdWait{
+		fd:    int(fd),
+		file:  os.NewFile(fd, "timerfd"),
+		fired: make(chan struct{}, 1),
+	}
+	go s.readLoop()
+	return s, nil
+}
+
+// readLoop 通过 netpoll 读取 timerfd 到期事件
+func (s *TimerfdWait) readLoop() {
+	var buf [8]byte
+	for {
+		if _, err := s.file.Read(buf[:]); err != nil {
+			return
+		}
+		select {
+		case s.fired <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Wait 实现 WaitStrategy
+func (s *TimerfdWait) Wait(w *Waiter, deadline time.Time) bool {
+	d := time.Until(deadline)
+	if d <= 0 {
+		return true
+	}
+
+	// 丢弃上一轮提前唤醒后残留的到期事件
+	select {
+	case <-s.fired:
+	default:
+	}
+
+	spec := itimerspec{value: syscall.NsecToTimespec(int64(d))}
+	_, _, errno := syscall.Syscall6(syscall.SYS_TIMERFD_SETTIME, uintptr(s.fd), 0,
+		uintptr(unsafe.Pointer(&spec)), 0, 0, 0)
+	if errno != 0 {
+		return w.Sleep(deadline)
+	}
+	return w.SleepOn(s.fired)
+}
+
+// Fd 返回 timerfd 文件描述符
+func (s *TimerfdWait) Fd() int {
+	return s.fd
+}
+
+// Close 关闭 timerfd
+func (s *TimerfdWait) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,35 @@
+//go:build linux
+
+package whTimer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimerfdWait(t *testing.T) {
+	s, err := NewTimerfdWait()
+	if err != nil {
+		t.Skipf("timerfd unavailable: %v", err)
+	}
+	defer s.Close()
+
+	var executed atomic.Int32
+	timer := NewTimer(func(e *Entry) {
+		e.Execute()
+	}, WithWaitStrategy(s))
+	timer.Start()
+	defer timer.Stop()
+
+	for i := 0; i < 5; i++ {
+		timer.AddEntry(time.Duration(5+i*5)*time.Millisecond, func() {
+			executed.Add(1)
+		})
+	}
+	time.Sleep(80 * time.Millisecond)
+
+	if executed.Load() != 5 {
+		t.Errorf("expected 5 executions, got %d", executed.Load())
+	}
+}
@@ -0,0 +1,49 @@
+package whTimer
+
+import (
+	"sync"
+)
+
+// watchers 条目结束时的通知回调，只有设置了 flagWatched 的条目才会查找
+var watchers struct {
+	sync.Mutex
+	m map[*Entry][]func()
+}
+
+// watch 在 gen 代条目结束（执行完成、取消或丢弃）时调用 fn，条目已结束或已被复用时返回 false
+// 重复执行的任务在最后一次执行完成后才算结束
+func (e *Entry) watch(gen uint64, fn func()) bool {
+	watchers.Lock()
+	defer watchers.Unlock()
+	for {
+		v := e.state.Load()
+		if v>>genShift != gen {
+			return false
+		}
+		if s := EntryState(v & stateMask); s != StateQueued && s != StateScheduled {
+			return false
+		}
+		if v&flagWatched != 0 || e.state.CompareAndSwap(v, v|flagWatched) {
+			break
+		}
+	}
+	if watchers.m == nil {
+		watchers.m = make(map[*Entry][]func())
+	}
+	watchers.m[e] = append(watchers.m[e], fn)
+	return true
+}
+
+// notifyDone 条目结束时调用已注册的通知，须在条目回收前调用
+func (e *Entry) notifyDone() {
+	if e.state.Load()&flagWatched == 0 {
+		return
+	}
+	watchers.Lock()
+	fns := watchers.m[e]
+	delete(watchers.m, e)
+	watchers.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
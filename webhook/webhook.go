@@ -0,0 +1,234 @@
+// Package webhook 基于 whTimer 调度 HTTP 回调，所有计时（包括重试间隔）均由时间轮完成
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"whTimer"
+)
+
+// 签名请求头
+const (
+	HeaderSignature = "X-Whtimer-Signature" // "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body))
+	HeaderTimestamp = "X-Whtimer-Timestamp" // Unix 秒
+)
+
+// Spec 回调请求定义
+type Spec struct {
+	Method  string // 默认 POST
+	URL     string
+	Header  http.Header
+	Body    []byte
+	Timeout time.Duration // 单次请求超时，默认 10s
+
+	MaxRetries   int           // 失败后的最大重试次数
+	RetryBackoff time.Duration // 首次重试间隔，之后每次翻倍，默认 1s
+	MaxBackoff   time.Duration // 重试间隔上限，默认 1h
+
+	Secret []byte // 非空时对请求签名
+}
+
+// Result 回调最终结果（成功或重试耗尽）
+type Result struct {
+	Spec       Spec
+	Attempts   int
+	StatusCode int
+	Err        error
+}
+
+// maxDrain 关闭响应前读取并丢弃的最大字节数，读完的连接才能被复用
+const maxDrain = 64 << 10
+
+// Delivery 一次已调度的回调，记录首次发送或下一次重试的条目以便取消
+type Delivery struct {
+	mu       sync.Mutex
+	entry    *whTimer.Entry
+	canceled bool
+}
+
+// Cancel 取消尚未发送的请求与之后的全部重试，正在进行的请求不受影响
+// 返回是否在取消前仍有待发送的请求
+func (d *Delivery) Cancel() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.canceled || d.entry == nil {
+		return false
+	}
+	d.canceled = true
+	return d.entry.Cancel()
+}
+
+// next 记录下一次重试的条目，已取消时返回 false
+func (d *Delivery) next(schedule func() *whTimer.Entry) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.canceled {
+		return false
+	}
+	d.entry = schedule()
+	return true
+}
+
+// finish 全部尝试结束，之后 Cancel 返回 false
+func (d *Delivery) finish() {
+	d.mu.Lock()
+	d.entry = nil
+	d.mu.Unlock()
+}
+
+// Scheduler 回调调度器
+type Scheduler struct {
+	timer    *whTimer.Timer
+	client   *http.Client
+	onResult func(Result)
+}
+
+// Option 调度器配置项
+type Option func(*Scheduler)
+
+// WithClient 设置 HTTP 客户端，默认 http.DefaultClient
+func WithClient(c *http.Client) Option {
+	return func(s *Scheduler) {
+		s.client = c
+	}
+}
+
+// WithResultHandler 设置结果回调，在发送请求的 goroutine 中调用
+func WithResultHandler(fn func(Result)) Option {
+	return func(s *Scheduler) {
+		s.onResult = fn
+	}
+}
+
+// New 创建回调调度器，timer 需由调用方启动
+func New(timer *whTimer.Timer, opts ...Option) *Scheduler {
+	s := &Scheduler{timer: timer, client: http.DefaultClient}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ScheduleWebhook 在 at 时刻发送 HTTP 请求，非 2xx 响应或请求失败时按退避重试
+// 请求在独立 goroutine 中发送，不阻塞 run loop；返回的 Delivery 可取消首次发送与之后的重试
+func (s *Scheduler) ScheduleWebhook(at time.Time, spec Spec) (*Delivery, error) {
+	if err := Validate(spec); err != nil {
+		return nil, err
+	}
+	if spec.Method == "" {
+		spec.Method = http.MethodPost
+	}
+	if spec.Timeout <= 0 {
+		spec.Timeout = 10 * time.Second
+	}
+	if spec.RetryBackoff <= 0 {
+		spec.RetryBackoff = time.Second
+	}
+	if spec.MaxBackoff <= 0 {
+		spec.MaxBackoff = time.Hour
+	}
+
+	d := &Delivery{}
+	d.next(func() *whTimer.Entry {
+		return s.timer.AddEntryAt(at, func() {
+			go s.attempt(d, spec, 1)
+		})
+	})
+	return d, nil
+}
+
+// Validate 检查回调定义是否有效
+func Validate(spec Spec) error {
+	u, err := url.Parse(spec.URL)
+	if err != nil {
+		return fmt.Errorf("webhook: invalid URL %q: %w", spec.URL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("webhook: unsupported URL scheme %q", u.Scheme)
+	}
+	return nil
+}
+
+func (s *Scheduler) attempt(d *Delivery, spec Spec, n int) {
+	status, err := s.send(spec)
+	if err == nil {
+		d.finish()
+		s.report(Result{Spec: spec, Attempts: n, StatusCode: status})
+		return
+	}
+	if n > spec.MaxRetries {
+		d.finish()
+		s.report(Result{Spec: spec, Attempts: n, StatusCode: status, Err: err})
+		return
+	}
+	d.next(func() *whTimer.Entry {
+		return s.timer.AddEntry(backoff(spec, n), func() {
+			go s.attempt(d, spec, n+1)
+		})
+	})
+}
+
+// backoff 第 n 次失败后的重试间隔，按 RetryBackoff 逐次翻倍，不超过 MaxBackoff
+func backoff(spec Spec, n int) time.Duration {
+	shift := n - 1
+	if shift >= 63 || spec.RetryBackoff > spec.MaxBackoff>>shift {
+		return spec.MaxBackoff
+	}
+	return spec.RetryBackoff << shift
+}
+
+func (s *Scheduler) send(spec Spec) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), spec.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, spec.Method, spec.URL, bytes.NewReader(spec.Body))
+	if err != nil {
+		return 0, err
+	}
+	for k, v := range spec.Header {
+		req.Header[k] = v
+	}
+	if len(spec.Secret) > 0 {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(HeaderTimestamp, ts)
+		req.Header.Set(HeaderSignature, "sha256="+Sign(spec.Secret, ts, spec.Body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrain))
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, errors.New("webhook: unexpected status " + resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+func (s *Scheduler) report(r Result) {
+	if s.onResult != nil {
+		s.onResult(r)
+	}
+}
+
+// Sign 计算签名，接收方可用于校验 HeaderSignature
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"whTimer"
+)
+
+func TestScheduleWebhook(t *testing.T) {
+	secret := []byte("s3cret")
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		want := "sha256=" + Sign(secret, r.Header.Get(HeaderTimestamp), body)
+		if r.Header.Get(HeaderSignature) != want || string(body) != "ping" {
+			t.Errorf("bad request: sig=%q body=%q", r.Header.Get(HeaderSignature), body)
+		}
+		// 前两次失败，验证重试
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	timer := whTimer.NewTimer(func(e *whTimer.Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	results := make(chan Result, 1)
+	s := New(timer, WithClient(srv.Client()), WithResultHandler(func(r Result) { results <- r }))
+	_, err := s.ScheduleWebhook(time.Now().Add(10*time.Millisecond), Spec{
+		URL:          srv.URL,
+		Body:         []byte("ping"),
+		MaxRetries:   3,
+		RetryBackoff: 5 * time.Millisecond,
+		Secret:       secret,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case r := <-results:
+		if r.Err != nil || r.Attempts != 3 || r.StatusCode != http.StatusOK {
+			t.Fatalf("result=%+v", r)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook not delivered")
+	}
+
+	if _, err := s.ScheduleWebhook(time.Now(), Spec{URL: "ftp://example.com"}); err == nil {
+		t.Fatal("unsupported scheme accepted")
+	}
+}
+
+func TestDeliveryCancel(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	timer := whTimer.NewTimer(func(e *whTimer.Entry) { e.Execute() })
+	timer.Start()
+	defer timer.Stop()
+
+	// 首次发送之后的重试同样可以取消
+	s := New(timer, WithClient(srv.Client()))
+	d, err := s.ScheduleWebhook(time.Now(), Spec{URL: srv.URL, MaxRetries: 1000, RetryBackoff: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for calls.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	d.Cancel()
+	time.Sleep(20 * time.Millisecond)
+	n := calls.Load()
+	time.Sleep(100 * time.Millisecond)
+	if calls.Load() != n || n < 2 {
+		t.Fatalf("calls=%d then %d, retries not canceled", n, calls.Load())
+	}
+
+	pending, _ := s.ScheduleWebhook(time.Now().Add(time.Hour), Spec{URL: srv.URL})
+	if !pending.Cancel() || pending.Cancel() {
+		t.Fatal("Cancel of an unsent delivery should succeed exactly once")
+	}
+}
+
+func TestBackoffCap(t *testing.T) {
+	spec := Spec{RetryBackoff: time.Second, MaxBackoff: time.Minute}
+	for n, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 6: 32 * time.Second, 7: time.Minute, 64: time.Minute, 1000: time.Minute} {
+		if got := backoff(spec, n); got != want {
+			t.Errorf("backoff(%d)=%v, want %v", n, got, want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package whTimer
 
 import (
+	"fmt"
 	"math/bits"
 	"sync/atomic"
 	"unsafe"
@@ -107,6 +108,41 @@ func (w *Wheel) RemoveEntry(entry *Entry, interval uint64) {
 	}
 }
 
+// Unlink 从 interval 对应的槽位中移除 entry，条目不在该槽位时返回 false 且不修改时间轮
+func (w *Wheel) Unlink(entry *Entry, interval uint64) bool {
+	index := w.getIndex(interval)
+
+	if w.level == 0 {
+		var prev *Entry
+		for e := w.entries[index]; e != nil; e = getNext(e) {
+			if e != entry {
+				prev = e
+				continue
+			}
+			if prev == nil {
+				w.entries[index] = getNext(e)
+				if w.entries[index] == nil {
+					w.bitmap &^= 1 << index
+				}
+			} else {
+				setNext(prev, getNext(e))
+			}
+			return true
+		}
+		return false
+	}
+
+	child := w.subWheels[index]
+	if child == nil || !child.Unlink(entry, interval) {
+		return false
+	}
+	if child.Empty() {
+		w.bitmap &^= 1 << index
+		w.subWheels[index] = nil
+	}
+	return true
+}
+
 // HandleExpiredEntries 处理过期的定时任务
 func (w *Wheel) HandleExpiredEntries(handler func(*Entry), remainingMs uint64) int {
 	count := 0
@@ -158,6 +194,84 @@ func (w *Wheel) NextExpirationTime() uint64 {
 	return index*msPerSlot[w.level] + w.subWheels[index].NextExpirationTime()
 }
 
+// Walk 按过期顺序遍历任务，fn 返回 false 时停止遍历
+func (w *Wheel) Walk(fn func(entry *Entry, ms uint64) bool) bool {
+	return w.walk(0, fn)
+}
+
+func (w *Wheel) walk(base uint64, fn func(*Entry, uint64) bool) bool {
+	for bitmap := w.bitmap; bitmap != 0; bitmap &= bitmap - 1 {
+		index := uint64(bits.TrailingZeros64(bitmap))
+
+		if w.level == 0 {
+			// 先取后继，fn 可能回收条目并清空其链接
+			for e := w.entries[index]; e != nil; {
+				next := getNext(e)
+				if !fn(e, base+index) {
+					return false
+				}
+				e = next
+			}
+		} else if !w.subWheels[index].walk(base+index*msPerSlot[w.level], fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate 检查时间轮的结构不变量，返回第一个违反项的描述
+// 检查层级范围、位图与槽位的一致性、子轮层级以及槽内链表的完整性（无环、无重复），
+// 可用于属性测试或在调试构建中作为损坏检测
+func (w *Wheel) Validate() error {
+	if w.level < 0 || w.level > MaxLevel {
+		return fmt.Errorf("whTimer: wheel level %d out of range [0, %d]", w.level, MaxLevel)
+	}
+	return w.validate(make(map[*Entry]struct{}))
+}
+
+func (w *Wheel) validate(seen map[*Entry]struct{}) error {
+	for i := range SlotSize {
+		bit := w.bitmap&(1<<i) != 0
+		entry, sub := w.entries[i], w.subWheels[i]
+
+		if w.level == 0 {
+			if sub != nil {
+				return fmt.Errorf("whTimer: level 0 slot %d has a sub-wheel", i)
+			}
+			if bit != (entry != nil) {
+				return fmt.Errorf("whTimer: level 0 slot %d bitmap=%v but entries present=%v", i, bit, entry != nil)
+			}
+			for e := entry; e != nil; e = getNext(e) {
+				if _, dup := seen[e]; dup {
+					return fmt.Errorf("whTimer: level 0 slot %d: entry %d linked more than once (cycle or shared node)", i, e.id)
+				}
+				seen[e] = struct{}{}
+			}
+			continue
+		}
+
+		if entry != nil {
+			return fmt.Errorf("whTimer: level %d slot %d holds entries directly", w.level, i)
+		}
+		if bit != (sub != nil) {
+			return fmt.Errorf("whTimer: level %d slot %d bitmap=%v but sub-wheel present=%v", w.level, i, bit, sub != nil)
+		}
+		if sub == nil {
+			continue
+		}
+		if sub.level != w.level-1 {
+			return fmt.Errorf("whTimer: level %d slot %d sub-wheel has level %d", w.level, i, sub.level)
+		}
+		if sub.Empty() {
+			return fmt.Errorf("whTimer: level %d slot %d sub-wheel is empty", w.level, i)
+		}
+		if err := sub.validate(seen); err != nil {
+			return fmt.Errorf("level %d slot %d: %w", w.level, i, err)
+		}
+	}
+	return nil
+}
+
 // Rotate 推进时间轮
 func (w *Wheel) Rotate(n uint64) {
 	if n == 0 || n >= SlotSize {